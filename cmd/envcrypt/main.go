@@ -0,0 +1,80 @@
+// Command envcrypt produces "enc:<version>:<base64>" values understood by
+// the env package getters.
+//
+// Usage:
+//
+//	envcrypt -genkey > key.txt
+//	envcrypt -key key.txt [-version v1] <plaintext>
+//	echo -n <plaintext> | envcrypt -key key.txt
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/4rchr4y/godevkit/v3/env"
+	"github.com/4rchr4y/godevkit/v3/syswrap"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "envcrypt:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("envcrypt", flag.ContinueOnError)
+	keyPath := fs.String("key", "", "path to the base64 encoded AES key file")
+	version := fs.String("version", "v1", "version tag the decrypter is registered under")
+	genKey := fs.Bool("genkey", false, "print a new base64 encoded 32 byte key and exit")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *genKey {
+		key, err := env.GenerateAESGCMKey(32)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(stdout, key)
+		return err
+	}
+
+	if *keyPath == "" {
+		return fmt.Errorf("-key is required")
+	}
+
+	aesgcm, err := env.NewAESGCMFromKeyFile(syswrap.OSWrap{}, *keyPath)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := readPlaintext(fs.Args(), stdin)
+	if err != nil {
+		return err
+	}
+
+	value, err := env.EncryptValue(*version, aesgcm, plaintext)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(stdout, value)
+	return err
+}
+
+func readPlaintext(args []string, stdin io.Reader) (string, error) {
+	switch len(args) {
+	case 0:
+		content, err := io.ReadAll(stdin)
+		return string(content), err
+	case 1:
+		return args[0], nil
+	default:
+		return "", fmt.Errorf("expected a single plaintext argument, got %d", len(args))
+	}
+}
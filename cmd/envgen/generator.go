@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/4rchr4y/godevkit/v3/env"
+)
+
+type fieldKind struct {
+	parser  string
+	literal func(value string) (string, error)
+}
+
+var kinds = map[string]fieldKind{
+	"string": {
+		literal: func(value string) (string, error) { return strconv.Quote(value), nil },
+	},
+	"int": {
+		parser: "env.ParseInt",
+		literal: func(value string) (string, error) {
+			v, err := env.ParseInt(value)
+			return strconv.Itoa(v), err
+		},
+	},
+	"uint64": {
+		parser: "env.ParseUint",
+		literal: func(value string) (string, error) {
+			v, err := env.ParseUint(value)
+			return strconv.FormatUint(v, 10), err
+		},
+	},
+	"float64": {
+		parser: "env.ParseFloat64",
+		literal: func(value string) (string, error) {
+			v, err := env.ParseFloat64(value)
+			if err == nil && (math.IsInf(v, 0) || math.IsNaN(v)) {
+				err = fmt.Errorf("value %s is not finite", value)
+			}
+			return strconv.FormatFloat(v, 'g', -1, 64), err
+		},
+	},
+	"time.Duration": {
+		parser: "env.ParseDuration",
+		literal: func(value string) (string, error) {
+			v, err := env.ParseDuration(value)
+			return fmt.Sprintf("%d // %s", int64(v), time.Duration(v)), err
+		},
+	},
+}
+
+type field struct {
+	Name       string
+	Key        string
+	Type       string
+	Parser     string
+	Default    string
+	RawDefault string
+	HasDefault bool
+	Doc        string
+}
+
+type config struct {
+	Package string
+	Type    string
+	Fields  []field
+}
+
+// parseConfig finds the struct typeName in files and collects its fields
+// tagged with `env:"KEY"` and an optional `default:"VALUE"`.
+func parseConfig(fset *token.FileSet, files []*ast.File, typeName string) (*config, error) {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if ts.Name.Name != typeName {
+					continue
+				}
+
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					return nil, fmt.Errorf("type %s is not a struct", typeName)
+				}
+
+				fields, err := parseFields(fset, st)
+				if err != nil {
+					return nil, fmt.Errorf("type %s: %w", typeName, err)
+				}
+
+				return &config{Package: file.Name.Name, Type: typeName, Fields: fields}, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("type %s was not found", typeName)
+}
+
+func parseFields(fset *token.FileSet, st *ast.StructType) ([]field, error) {
+	var fields []field
+	for _, f := range st.Fields.List {
+		if f.Tag == nil || len(f.Names) == 0 {
+			continue
+		}
+
+		tagValue, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		tag := reflect.StructTag(tagValue)
+		key, ok := tag.Lookup("env")
+		if !ok {
+			continue
+		}
+
+		var typ bytes.Buffer
+		if err := format.Node(&typ, fset, f.Type); err != nil {
+			return nil, err
+		}
+
+		kind, ok := kinds[typ.String()]
+		if !ok {
+			return nil, fmt.Errorf("%s: unsupported field type %s", fset.Position(f.Pos()), typ.String())
+		}
+
+		for _, name := range f.Names {
+			fd := field{
+				Name:   name.Name,
+				Key:    key,
+				Type:   typ.String(),
+				Parser: kind.parser,
+				Doc:    strings.Join(strings.Fields(f.Doc.Text()+f.Comment.Text()), " "),
+			}
+
+			if value, ok := tag.Lookup("default"); ok {
+				literal, err := kind.literal(value)
+				if err != nil {
+					return nil, fmt.Errorf("%s: invalid default for %s: %w", fset.Position(f.Pos()), name.Name, err)
+				}
+
+				fd.Default, fd.RawDefault, fd.HasDefault = literal, value, true
+			}
+
+			fields = append(fields, fd)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields with env tags")
+	}
+
+	return fields, nil
+}
+
+var funcs = template.FuncMap{"quote": strconv.Quote}
+
+var loaderTemplate = template.Must(template.New("loader").Funcs(funcs).Parse(`// Code generated by envgen; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/4rchr4y/godevkit/v3/env"
+)
+
+// Load{{.Type}} reads {{.Type}} from src.
+func Load{{.Type}}(src env.Source) ({{.Type}}, error) {
+	var (
+		cfg  {{.Type}}
+		errs []error
+	)
+{{range .Fields}}
+	if raw, ok := src.LookupEnv({{quote .Key}}); ok {
+		if value, err := env.ResolveValue(raw); err != nil {
+			errs = append(errs, fmt.Errorf("invalid environment variable '%s' value: %w", {{quote .Key}}, err))
+{{- if .Parser}}
+		} else if cfg.{{.Name}}, err = {{.Parser}}(value); err != nil {
+			errs = append(errs, env.InvalidValueError({{quote .Key}}, raw, false, err))
+{{- else}}
+		} else {
+			cfg.{{.Name}} = value
+{{- end}}
+		}
+	} else {
+{{- if .HasDefault}}
+		cfg.{{.Name}} = {{.Default}}
+{{- else}}
+		errs = append(errs, &env.NotFoundError{Key: {{quote .Key}}})
+{{- end}}
+	}
+{{end}}
+	return cfg, errors.Join(errs...)
+}
+`))
+
+var docTemplate = template.Must(template.New("doc").Parse(`# {{.Type}} environment variables
+
+<!-- Code generated by envgen; DO NOT EDIT. -->
+
+| Variable | Type | Default | Required | Description |
+|----------|------|---------|----------|-------------|
+{{- range .Fields}}
+| ` + "`{{.Key}}`" + ` | {{.Type}} | {{if .HasDefault}}` + "`{{.RawDefault}}`" + `{{end}} | {{if .HasDefault}}no{{else}}yes{{end}} | {{.Doc}} |
+{{- end}}
+`))
+
+func generateLoader(cfg *config) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := loaderTemplate.Execute(&buf, cfg); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func generateDoc(cfg *config) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := docTemplate.Execute(&buf, cfg); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// parseDir parses the non-test Go files of dir in name order, skipping
+// the previously generated output.
+func parseDir(fset *token.FileSet, dir string, exclude string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == exclude {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseTestConfig(t *testing.T, src string, typeName string) (*config, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "config.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	return parseConfig(fset, []*ast.File{file}, typeName)
+}
+
+const testConfigSource = `package app
+
+import "time"
+
+type Config struct {
+	// Port the server listens on.
+	Port    int           ` + "`env:\"APP_PORT\" default:\"8080\"`" + `
+	Host    string        ` + "`env:\"APP_HOST\"`" + `
+	Timeout time.Duration ` + "`env:\"APP_TIMEOUT\" default:\"5s\"`" + `
+	skipped string
+}
+`
+
+func TestParseConfig(t *testing.T) {
+	t.Run("valid: tagged fields", func(t *testing.T) {
+		cfg, err := parseTestConfig(t, testConfigSource, "Config")
+
+		require.NoError(t, err)
+		assert.Equal(t, "app", cfg.Package)
+		assert.Equal(t, []field{
+			{Name: "Port", Key: "APP_PORT", Type: "int", Parser: "env.ParseInt", Default: "8080", RawDefault: "8080", HasDefault: true, Doc: "Port the server listens on."},
+			{Name: "Host", Key: "APP_HOST", Type: "string"},
+			{Name: "Timeout", Key: "APP_TIMEOUT", Type: "time.Duration", Parser: "env.ParseDuration", Default: "5000000000 // 5s", RawDefault: "5s", HasDefault: true},
+		}, cfg.Fields)
+	})
+
+	t.Run("invalid: type not found", func(t *testing.T) {
+		_, err := parseTestConfig(t, testConfigSource, "Missing")
+
+		assert.EqualError(t, err, "type Missing was not found")
+	})
+
+	t.Run("invalid: unsupported field type", func(t *testing.T) {
+		_, err := parseTestConfig(t, "package app\ntype Config struct {\n\tDebug bool `env:\"DEBUG\"`\n}\n", "Config")
+
+		assert.ErrorContains(t, err, "unsupported field type bool")
+	})
+
+	t.Run("invalid: default does not parse", func(t *testing.T) {
+		_, err := parseTestConfig(t, "package app\ntype Config struct {\n\tPort int `env:\"PORT\" default:\"http\"`\n}\n", "Config")
+
+		assert.ErrorContains(t, err, "invalid default for Port")
+	})
+
+	t.Run("invalid: non-finite float default", func(t *testing.T) {
+		for _, value := range []string{"Inf", "-Inf", "NaN"} {
+			_, err := parseTestConfig(t, "package app\ntype Config struct {\n\tRatio float64 `env:\"RATIO\" default:\""+value+"\"`\n}\n", "Config")
+
+			assert.ErrorContains(t, err, "is not finite", value)
+		}
+	})
+}
+
+func TestGenerateLoader(t *testing.T) {
+	cfg, err := parseTestConfig(t, testConfigSource, "Config")
+	require.NoError(t, err)
+
+	loader, err := generateLoader(cfg)
+
+	require.NoError(t, err)
+	assert.Contains(t, string(loader), "func LoadConfig(src env.Source) (Config, error) {")
+	assert.Contains(t, string(loader), "} else if cfg.Port, err = env.ParseInt(value); err != nil {")
+	assert.Contains(t, string(loader), "errs = append(errs, env.InvalidValueError(\"APP_PORT\", raw, false, err))")
+	assert.Contains(t, string(loader), "cfg.Port = 8080")
+	assert.Contains(t, string(loader), "cfg.Host = value")
+	assert.Contains(t, string(loader), "errs = append(errs, &env.NotFoundError{Key: \"APP_HOST\"})")
+	assert.Contains(t, string(loader), "cfg.Timeout = 5000000000 // 5s")
+}
+
+func TestGenerateLoaderTypeChecks(t *testing.T) {
+	src := `package app
+
+import "time"
+
+type Config struct {
+	Port    int           ` + "`env:\"APP_PORT\" default:\"8080\"`" + `
+	Host    string        ` + "`env:\"APP_HOST\" default:\"localhost\"`" + `
+	Workers uint64        ` + "`env:\"APP_WORKERS\" default:\"4\"`" + `
+	Ratio   float64       ` + "`env:\"APP_RATIO\" default:\"0.5\"`" + `
+	Whole   float64       ` + "`env:\"APP_WHOLE\" default:\"2\"`" + `
+	Timeout time.Duration ` + "`env:\"APP_TIMEOUT\" default:\"5s\"`" + `
+	Secret  string        ` + "`env:\"APP_SECRET\"`" + `
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "config.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	cfg, err := parseConfig(fset, []*ast.File{file}, "Config")
+	require.NoError(t, err)
+
+	loader, err := generateLoader(cfg)
+	require.NoError(t, err)
+
+	generated, err := parser.ParseFile(fset, "config_env.go", loader, 0)
+	require.NoError(t, err)
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("app", fset, []*ast.File{file, generated}, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestGenerateLoaderExampleIsCurrent(t *testing.T) {
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, filepath.Join("internal", "example"), "config_env.go")
+	require.NoError(t, err)
+
+	cfg, err := parseConfig(fset, files, "Config")
+	require.NoError(t, err)
+
+	loader, err := generateLoader(cfg)
+	require.NoError(t, err)
+
+	current, err := os.ReadFile(filepath.Join("internal", "example", "config_env.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(current), string(loader), "run go generate ./cmd/envgen/internal/example")
+}
+
+func TestGenerateDoc(t *testing.T) {
+	cfg, err := parseTestConfig(t, testConfigSource, "Config")
+	require.NoError(t, err)
+
+	doc, err := generateDoc(cfg)
+
+	require.NoError(t, err)
+	assert.Contains(t, string(doc), "| `APP_PORT` | int | `8080` | no | Port the server listens on. |")
+	assert.Contains(t, string(doc), "| `APP_HOST` | string |  | yes |  |")
+}
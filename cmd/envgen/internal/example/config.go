@@ -0,0 +1,17 @@
+// Package example holds a config whose loader is generated by envgen, so
+// that the generated code is compiled and exercised by the tests.
+package example
+
+import "time"
+
+//go:generate go run github.com/4rchr4y/godevkit/v3/cmd/envgen -type Config
+
+type Config struct {
+	// Port the server listens on.
+	Port int `env:"PORT" default:"8080"`
+	// Host the server binds to.
+	Host    string        `env:"HOST"`
+	Workers uint64        `env:"WORKERS" default:"4"`
+	Ratio   float64       `env:"RATIO" default:"0.5"`
+	Timeout time.Duration `env:"TIMEOUT" default:"5s"`
+}
@@ -0,0 +1,70 @@
+// Code generated by envgen; DO NOT EDIT.
+
+package example
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/4rchr4y/godevkit/v3/env"
+)
+
+// LoadConfig reads Config from src.
+func LoadConfig(src env.Source) (Config, error) {
+	var (
+		cfg  Config
+		errs []error
+	)
+
+	if raw, ok := src.LookupEnv("PORT"); ok {
+		if value, err := env.ResolveValue(raw); err != nil {
+			errs = append(errs, fmt.Errorf("invalid environment variable '%s' value: %w", "PORT", err))
+		} else if cfg.Port, err = env.ParseInt(value); err != nil {
+			errs = append(errs, env.InvalidValueError("PORT", raw, false, err))
+		}
+	} else {
+		cfg.Port = 8080
+	}
+
+	if raw, ok := src.LookupEnv("HOST"); ok {
+		if value, err := env.ResolveValue(raw); err != nil {
+			errs = append(errs, fmt.Errorf("invalid environment variable '%s' value: %w", "HOST", err))
+		} else {
+			cfg.Host = value
+		}
+	} else {
+		errs = append(errs, &env.NotFoundError{Key: "HOST"})
+	}
+
+	if raw, ok := src.LookupEnv("WORKERS"); ok {
+		if value, err := env.ResolveValue(raw); err != nil {
+			errs = append(errs, fmt.Errorf("invalid environment variable '%s' value: %w", "WORKERS", err))
+		} else if cfg.Workers, err = env.ParseUint(value); err != nil {
+			errs = append(errs, env.InvalidValueError("WORKERS", raw, false, err))
+		}
+	} else {
+		cfg.Workers = 4
+	}
+
+	if raw, ok := src.LookupEnv("RATIO"); ok {
+		if value, err := env.ResolveValue(raw); err != nil {
+			errs = append(errs, fmt.Errorf("invalid environment variable '%s' value: %w", "RATIO", err))
+		} else if cfg.Ratio, err = env.ParseFloat64(value); err != nil {
+			errs = append(errs, env.InvalidValueError("RATIO", raw, false, err))
+		}
+	} else {
+		cfg.Ratio = 0.5
+	}
+
+	if raw, ok := src.LookupEnv("TIMEOUT"); ok {
+		if value, err := env.ResolveValue(raw); err != nil {
+			errs = append(errs, fmt.Errorf("invalid environment variable '%s' value: %w", "TIMEOUT", err))
+		} else if cfg.Timeout, err = env.ParseDuration(value); err != nil {
+			errs = append(errs, env.InvalidValueError("TIMEOUT", raw, false, err))
+		}
+	} else {
+		cfg.Timeout = 5000000000 // 5s
+	}
+
+	return cfg, errors.Join(errs...)
+}
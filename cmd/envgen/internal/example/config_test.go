@@ -0,0 +1,43 @@
+package example
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4rchr4y/godevkit/v3/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeExec struct {
+	output string
+}
+
+func (f fakeExec) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return []byte(f.output), nil
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("valid: defaults and resolved values", func(t *testing.T) {
+		defer env.SetExecResolver(env.NewExecResolver(fakeExec{output: "db.internal\n"}, time.Second, "pass"))()
+
+		cfg, err := LoadConfig(env.MapSource{"HOST": "exec:pass show host"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, Config{Port: 8080, Host: "db.internal", Workers: 4, Ratio: 0.5, Timeout: 5 * time.Second}, cfg)
+	})
+
+	t.Run("invalid: exec output is not included in errors", func(t *testing.T) {
+		defer env.SetExecResolver(env.NewExecResolver(fakeExec{output: "hunter2"}, time.Second, "pass"))()
+
+		_, err := LoadConfig(env.MapSource{"HOST": "localhost", "PORT": "exec:pass show port"})
+
+		assert.EqualError(t, err, "invalid environment variable 'PORT' value: strconv.Atoi: invalid syntax (value redacted)")
+	})
+
+	t.Run("invalid: plain values are reported", func(t *testing.T) {
+		_, err := LoadConfig(env.MapSource{"HOST": "localhost", "PORT": "http"})
+
+		assert.EqualError(t, err, `invalid environment variable 'PORT' value: strconv.Atoi: parsing "http": invalid syntax`)
+	})
+}
@@ -0,0 +1,91 @@
+// Command envgen generates reflection-free loaders for config structs whose
+// fields carry `env:"KEY"` and optional `default:"VALUE"` tags.
+//
+// Usage:
+//
+//	//go:generate go run github.com/4rchr4y/godevkit/v3/cmd/envgen -type Config
+//
+// For the type Config it writes config_env.go with
+//
+//	func LoadConfig(src env.Source) (Config, error)
+//
+// and config_env.md documenting every variable. Supported field types are
+// string, int, uint64, float64 and time.Duration; URLs and enums are not
+// validated, so such fields are declared as string and checked with
+// env.ParseUrl or an env.Enum after loading. Fields without a default are
+// required.
+//
+// Encrypted and exec values are resolved and kept out of error messages,
+// but the generated loader does not report accesses to the recorder set
+// with env.SetRecorder; read variables through env.Var where that matters.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "envgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("envgen", flag.ContinueOnError)
+	typeName := fs.String("type", "", "name of the config struct")
+	dir := fs.String("dir", ".", "directory of the package declaring the struct")
+	output := fs.String("output", "", "loader file name, defaults to <type>_env.go")
+	doc := fs.String("doc", "", "documentation file name, defaults to <type>_env.md, \"-\" disables it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *typeName == "" {
+		return fmt.Errorf("-type is required")
+	}
+
+	base := strings.ToLower(*typeName) + "_env"
+	if *output == "" {
+		*output = base + ".go"
+	}
+	if *doc == "" {
+		*doc = base + ".md"
+	}
+
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, *dir, *output)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := parseConfig(fset, files, *typeName)
+	if err != nil {
+		return err
+	}
+
+	loader, err := generateLoader(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(*dir, *output), loader, 0o644); err != nil {
+		return err
+	}
+
+	if *doc == "-" {
+		return nil
+	}
+
+	content, err := generateDoc(cfg)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(*dir, *doc), content, 0o644)
+}
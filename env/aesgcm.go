@@ -0,0 +1,80 @@
+package env
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/4rchr4y/godevkit/v3/syswrap/osiface"
+)
+
+// AESGCM encrypts and decrypts values with AES in GCM mode. The nonce is
+// generated per value and stored in front of the sealed data.
+type AESGCM struct {
+	aead cipher.AEAD
+}
+
+func NewAESGCM(key []byte) (*AESGCM, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AESGCM{aead: aead}, nil
+}
+
+// NewAESGCMFromKeyFile reads a base64 encoded 16, 24 or 32 byte key.
+func NewAESGCMFromKeyFile(osw osiface.OSWrapper, path string) (*AESGCM, error) {
+	content, err := osw.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file '%s': %w", path, err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(content)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key file '%s': %w", path, err)
+	}
+
+	return NewAESGCM(key)
+}
+
+func GenerateAESGCMKey(size int) (string, error) {
+	key := make([]byte, size)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+
+	if _, err := aes.NewCipher(key); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func (a *AESGCM) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, a.aead.NonceSize(), a.aead.NonceSize()+len(plaintext)+a.aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return a.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (a *AESGCM) Decrypt(ciphertext []byte) ([]byte, error) {
+	nonceSize := a.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	return a.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], nil)
+}
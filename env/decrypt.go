@@ -0,0 +1,85 @@
+package env
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// EncryptedPrefix marks values in the form "enc:<version>:<base64>" that
+// are decrypted by the Decrypter registered for <version> before parsing.
+const EncryptedPrefix = "enc:"
+
+type Decrypter interface {
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+type Encrypter interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+}
+
+var (
+	decryptersMu sync.RWMutex
+	decrypters   = make(map[string]Decrypter)
+)
+
+// RegisterDecrypter installs d for values tagged with version. The returned
+// function restores the previously registered decrypter, if any.
+func RegisterDecrypter(version string, d Decrypter) (restore func()) {
+	decryptersMu.Lock()
+	prev, existed := decrypters[version]
+	decrypters[version] = d
+	decryptersMu.Unlock()
+
+	return func() {
+		decryptersMu.Lock()
+		defer decryptersMu.Unlock()
+
+		if existed {
+			decrypters[version] = prev
+			return
+		}
+		delete(decrypters, version)
+	}
+}
+
+func EncryptValue(version string, e Encrypter, plaintext string) (string, error) {
+	if version == "" || strings.Contains(version, ":") {
+		return "", fmt.Errorf("invalid encryption version '%s'", version)
+	}
+
+	ciphertext, err := e.Encrypt([]byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return EncryptedPrefix + version + ":" + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+func DecryptValue(value string) (string, error) {
+	version, payload, ok := strings.Cut(strings.TrimPrefix(value, EncryptedPrefix), ":")
+	if !ok || version == "" {
+		return "", fmt.Errorf("malformed encrypted value, expected '%s<version>:<base64>'", EncryptedPrefix)
+	}
+
+	decryptersMu.RLock()
+	d, ok := decrypters[version]
+	decryptersMu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("no decrypter registered for version '%s'", version)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode encrypted value: %w", err)
+	}
+
+	plaintext, err := d.Decrypt(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,111 @@
+package env
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/4rchr4y/godevkit/v3/syswrap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAESGCM(t *testing.T) *AESGCM {
+	aesgcm, err := NewAESGCM([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	return aesgcm
+}
+
+func TestDecryptValue(t *testing.T) {
+	aesgcm := newTestAESGCM(t)
+	defer RegisterDecrypter("v1", aesgcm)()
+
+	t.Run("valid: round trip", func(t *testing.T) {
+		value, err := EncryptValue("v1", aesgcm, "secret")
+		require.NoError(t, err)
+
+		plaintext, err := DecryptValue(value)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", plaintext)
+	})
+
+	t.Run("invalid: unknown version", func(t *testing.T) {
+		_, err := DecryptValue("enc:v2:AAAA")
+
+		assert.EqualError(t, err, "no decrypter registered for version 'v2'")
+	})
+
+	t.Run("invalid: malformed value", func(t *testing.T) {
+		_, err := DecryptValue("enc:v1")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid: tampered ciphertext", func(t *testing.T) {
+		value, err := EncryptValue("v1", aesgcm, "secret")
+		require.NoError(t, err)
+
+		_, err = DecryptValue(value[:len(value)-4] + "AAAA")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestEncryptedLookup(t *testing.T) {
+	aesgcm := newTestAESGCM(t)
+	defer RegisterDecrypter("v1", aesgcm)()
+
+	t.Run("valid: typed getter decrypts value", func(t *testing.T) {
+		value, err := EncryptValue("v1", aesgcm, "8080")
+		require.NoError(t, err)
+
+		os.Setenv("TEST_ENCRYPTED_INT", value)
+		defer os.Unsetenv("TEST_ENCRYPTED_INT")
+
+		assert.Equal(t, 8080, MustGetInt("TEST_ENCRYPTED_INT"))
+	})
+
+	t.Run("invalid: undecryptable value falls back to default", func(t *testing.T) {
+		os.Setenv("TEST_ENCRYPTED_STRING", "enc:v9:AAAA")
+		defer os.Unsetenv("TEST_ENCRYPTED_STRING")
+
+		assert.Equal(t, "default", GetStringWithDefault("TEST_ENCRYPTED_STRING", "default"))
+		assert.Panics(t, func() { MustGetString("TEST_ENCRYPTED_STRING") })
+	})
+}
+
+func TestNewAESGCMFromKeyFile(t *testing.T) {
+	t.Run("valid: base64 key file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "key")
+		key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+		require.NoError(t, os.WriteFile(path, []byte(key+"\n"), 0o600))
+
+		fromFile, err := NewAESGCMFromKeyFile(syswrap.OSWrap{}, path)
+		require.NoError(t, err)
+
+		ciphertext, err := newTestAESGCM(t).Encrypt([]byte("secret"))
+		require.NoError(t, err)
+
+		plaintext, err := fromFile.Decrypt(ciphertext)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", string(plaintext))
+	})
+
+	t.Run("invalid: missing key file", func(t *testing.T) {
+		_, err := NewAESGCMFromKeyFile(syswrap.OSWrap{}, filepath.Join(t.TempDir(), "missing"))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid: wrong key size", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "key")
+		require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString([]byte("short"))), 0o600))
+
+		_, err := NewAESGCMFromKeyFile(syswrap.OSWrap{}, path)
+
+		assert.Error(t, err)
+	})
+}
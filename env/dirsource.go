@@ -0,0 +1,86 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/4rchr4y/godevkit/v3/syswrap/osiface"
+)
+
+// KeyNormalizer maps a file name to an environment variable key.
+type KeyNormalizer func(name string) string
+
+// UpperSnakeCase turns "db.host-name" into "DB_HOST_NAME".
+func UpperSnakeCase(name string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+// LoadDir reads a directory holding one file per key, such as a mounted
+// Kubernetes ConfigMap or Secret. dir and its entries may be symlinks.
+// Entries starting with ".." (the "..data" symlink and the timestamped
+// directories behind it) and subdirectories are ignored. A single trailing
+// newline is trimmed from each value. A nil normalize keeps file names as
+// they are.
+func LoadDir(osw osiface.OSWrapper, dir string, normalize KeyNormalizer) (MapSource, error) {
+	values, err := loadDir(osw, dir, normalize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load directory '%s': %w", dir, err)
+	}
+
+	return values, nil
+}
+
+func loadDir(osw osiface.OSWrapper, dir string, normalize KeyNormalizer) (MapSource, error) {
+	info, err := osw.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, errors.New("not a directory")
+	}
+
+	f, err := osw.OpenFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	names, err := f.Readdirnames(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(MapSource)
+	for _, name := range names {
+		if strings.HasPrefix(name, "..") {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		info, err := osw.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		content, err := osw.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		key := name
+		if normalize != nil {
+			key = normalize(key)
+		}
+
+		value := strings.TrimSuffix(string(content), "\n")
+		values[key] = strings.TrimSuffix(value, "\r")
+	}
+
+	return values, nil
+}
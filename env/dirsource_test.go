@@ -0,0 +1,100 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/4rchr4y/godevkit/v3/syswrap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigMap mimics the layout kubelet uses for ConfigMap volumes:
+// the files live in a timestamped directory, "..data" points at it and
+// every key is a symlink through "..data".
+func writeConfigMap(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	dataDir := filepath.Join(dir, "..2024_01_01_00_00_00.000000000")
+	require.NoError(t, os.Mkdir(dataDir, 0o755))
+	require.NoError(t, os.Symlink(filepath.Base(dataDir), filepath.Join(dir, "..data")))
+
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dataDir, name), []byte(content), 0o644))
+		require.NoError(t, os.Symlink(filepath.Join("..data", name), filepath.Join(dir, name)))
+	}
+
+	return dir
+}
+
+func TestLoadDir(t *testing.T) {
+	t.Run("valid: configmap layout", func(t *testing.T) {
+		dir := writeConfigMap(t, map[string]string{
+			"db.host":   "localhost\n",
+			"log-level": "debug",
+		})
+
+		values, err := LoadDir(syswrap.OSWrap{}, dir, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, MapSource{"db.host": "localhost", "log-level": "debug"}, values)
+	})
+
+	t.Run("valid: normalized keys", func(t *testing.T) {
+		dir := writeConfigMap(t, map[string]string{"db.host-name": "localhost"})
+
+		values, err := LoadDir(syswrap.OSWrap{}, dir, UpperSnakeCase)
+
+		assert.NoError(t, err)
+		assert.Equal(t, MapSource{"DB_HOST_NAME": "localhost"}, values)
+	})
+
+	t.Run("valid: subdirectories are ignored", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "KEY"), []byte("value"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "PORT"), []byte("8080"), 0o644))
+
+		values, err := LoadDir(syswrap.OSWrap{}, dir, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, MapSource{"PORT": "8080"}, values)
+	})
+
+	t.Run("valid: root is a symlink", func(t *testing.T) {
+		dir := writeConfigMap(t, map[string]string{"PORT": "8080"})
+		link := filepath.Join(t.TempDir(), "link")
+		require.NoError(t, os.Symlink(dir, link))
+
+		values, err := LoadDir(syswrap.OSWrap{}, link, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, MapSource{"PORT": "8080"}, values)
+	})
+
+	t.Run("valid: in-memory filesystem", func(t *testing.T) {
+		osw := syswrap.NewMemOSWrap()
+		require.NoError(t, osw.MkdirAll("/config/nested", 0o755))
+		require.NoError(t, osw.WriteFile("/config/PORT", []byte("8080\n"), 0o644))
+
+		values, err := LoadDir(osw, "/config", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, MapSource{"PORT": "8080"}, values)
+	})
+
+	t.Run("invalid: root is a file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "PORT")
+		require.NoError(t, os.WriteFile(file, []byte("8080"), 0o644))
+
+		_, err := LoadDir(syswrap.OSWrap{}, file, nil)
+
+		assert.ErrorContains(t, err, "not a directory")
+	})
+
+	t.Run("invalid: missing directory", func(t *testing.T) {
+		_, err := LoadDir(syswrap.OSWrap{}, filepath.Join(t.TempDir(), "missing"), nil)
+
+		assert.Error(t, err)
+	})
+}
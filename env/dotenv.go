@@ -0,0 +1,118 @@
+package env
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseDotenv reads KEY=VALUE pairs in the dotenv format. Blank lines and
+// lines starting with '#' are ignored, an optional "export " prefix is
+// accepted, single quoted values are taken literally and double quoted
+// values support \n, \r, \t, \" and \\ escapes. Unquoted values end at " #".
+func ParseDotenv(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, err := parseDotenvLine(strings.TrimPrefix(line, "export "))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		values[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func parseDotenvLine(line string) (string, string, error) {
+	key, value, ok := strings.Cut(line, "=")
+	if !ok {
+		return "", "", fmt.Errorf("expected KEY=VALUE, got '%s'", line)
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" || strings.ContainsAny(key, " \t") {
+		return "", "", fmt.Errorf("invalid key '%s'", key)
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return key, "", nil
+	}
+
+	switch value[0] {
+	case '\'':
+		end := strings.IndexByte(value[1:], '\'')
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated single quoted value for key '%s'", key)
+		}
+
+		return key, value[1 : end+1], checkTrailing(key, value[end+2:])
+
+	case '"':
+		unquoted, rest, err := unquoteDouble(value[1:])
+		if err != nil {
+			return "", "", fmt.Errorf("%w for key '%s'", err, key)
+		}
+
+		return key, unquoted, checkTrailing(key, rest)
+
+	default:
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+
+		return key, value, nil
+	}
+}
+
+func unquoteDouble(value string) (string, string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '"':
+			return sb.String(), value[i+1:], nil
+		case '\\':
+			if i+1 == len(value) {
+				return "", "", fmt.Errorf("unterminated double quoted value")
+			}
+
+			i++
+			switch value[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(value[i])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return "", "", fmt.Errorf("unterminated double quoted value")
+}
+
+func checkTrailing(key string, rest string) error {
+	rest = strings.TrimSpace(rest)
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		return fmt.Errorf("unexpected characters after quoted value for key '%s'", key)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package env
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDotenv(t *testing.T) {
+	t.Run("valid: plain, quoted and exported values", func(t *testing.T) {
+		input := `
+# comment
+PORT=8080
+export HOST = localhost
+EMPTY=
+SINGLE='raw \n value' # comment
+DOUBLE="line\nbreak \"quoted\""
+INLINE=value # comment
+HASH=a#b
+`
+		values, err := ParseDotenv(strings.NewReader(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"PORT":   "8080",
+			"HOST":   "localhost",
+			"EMPTY":  "",
+			"SINGLE": `raw \n value`,
+			"DOUBLE": "line\nbreak \"quoted\"",
+			"INLINE": "value",
+			"HASH":   "a#b",
+		}, values)
+	})
+
+	t.Run("valid: later keys override earlier ones", func(t *testing.T) {
+		values, err := ParseDotenv(strings.NewReader("KEY=one\nKEY=two\n"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"KEY": "two"}, values)
+	})
+
+	t.Run("invalid: missing separator", func(t *testing.T) {
+		_, err := ParseDotenv(strings.NewReader("PORT=8080\nINVALID\n"))
+
+		assert.EqualError(t, err, "line 2: expected KEY=VALUE, got 'INVALID'")
+	})
+
+	t.Run("invalid: unterminated quote", func(t *testing.T) {
+		_, err := ParseDotenv(strings.NewReader(`KEY="value`))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid: text after quoted value", func(t *testing.T) {
+		_, err := ParseDotenv(strings.NewReader(`KEY='value' trailing`))
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,61 @@
+package env
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Enum maps the allowed string values of a variable to typed constants.
+type Enum[T any] struct {
+	values   map[string]T
+	foldCase bool
+}
+
+func NewEnum[T any](values map[string]T) Enum[T] {
+	return Enum[T]{values: values}
+}
+
+// CaseInsensitive returns a copy of the enum that matches values regardless
+// of their case.
+func (e Enum[T]) CaseInsensitive() Enum[T] {
+	folded := make(map[string]T, len(e.values))
+	for name, value := range e.values {
+		folded[strings.ToLower(name)] = value
+	}
+
+	return Enum[T]{values: folded, foldCase: true}
+}
+
+// Allowed returns the sorted list of accepted values.
+func (e Enum[T]) Allowed() []string {
+	allowed := make([]string, 0, len(e.values))
+	for name := range e.values {
+		allowed = append(allowed, name)
+	}
+
+	sort.Strings(allowed)
+	return allowed
+}
+
+func (e Enum[T]) Parse(value string) (T, error) {
+	name := value
+	if e.foldCase {
+		name = strings.ToLower(value)
+	}
+
+	v, ok := e.values[name]
+	if !ok {
+		return v, fmt.Errorf("value '%s' is not one of [%s]", value, strings.Join(e.Allowed(), ", "))
+	}
+
+	return v, nil
+}
+
+func MustGetEnum[T any](key string, enum Enum[T]) T {
+	return EnumVar(key, enum).mustSkip(1)
+}
+
+func GetEnumWithDefault[T any](key string, enum Enum[T], defaultValue T) T {
+	return EnumVar(key, enum).Default(defaultValue).Get()
+}
@@ -0,0 +1,86 @@
+package env
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMode int
+
+const (
+	testModeDev testMode = iota
+	testModeStaging
+	testModeProd
+)
+
+var testModes = NewEnum(map[string]testMode{
+	"dev":     testModeDev,
+	"staging": testModeStaging,
+	"prod":    testModeProd,
+})
+
+func TestEnumParse(t *testing.T) {
+	t.Run("valid: known value", func(t *testing.T) {
+		value, err := testModes.Parse("staging")
+
+		assert.NoError(t, err)
+		assert.Equal(t, testModeStaging, value)
+	})
+
+	t.Run("valid: case insensitive", func(t *testing.T) {
+		value, err := testModes.CaseInsensitive().Parse("PROD")
+
+		assert.NoError(t, err)
+		assert.Equal(t, testModeProd, value)
+	})
+
+	t.Run("invalid: case sensitive by default", func(t *testing.T) {
+		_, err := testModes.Parse("PROD")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid: unknown value lists allowed set", func(t *testing.T) {
+		_, err := testModes.Parse("qa")
+
+		assert.EqualError(t, err, "value 'qa' is not one of [dev, prod, staging]")
+	})
+}
+
+func TestMustGetEnum(t *testing.T) {
+	t.Run("valid: valid env variable", func(t *testing.T) {
+		os.Setenv("TEST_VALID_MODE", "prod")
+		defer os.Unsetenv("TEST_VALID_MODE")
+
+		assert.Equal(t, testModeProd, MustGetEnum("TEST_VALID_MODE", testModes))
+	})
+
+	t.Run("invalid: unknown value", func(t *testing.T) {
+		os.Setenv("TEST_INVALID_MODE", "qa")
+		defer os.Unsetenv("TEST_INVALID_MODE")
+
+		assert.Panics(t, func() { MustGetEnum("TEST_INVALID_MODE", testModes) })
+	})
+
+	t.Run("invalid: env key is not set", func(t *testing.T) {
+		assert.Panics(t, func() { MustGetEnum("TEST_NONEXISTENT_KEY", testModes) })
+	})
+}
+
+func TestGetEnumWithDefault(t *testing.T) {
+	t.Run("valid: valid env variable", func(t *testing.T) {
+		os.Setenv("TEST_VALID_MODE", "dev")
+		defer os.Unsetenv("TEST_VALID_MODE")
+
+		assert.Equal(t, testModeDev, GetEnumWithDefault("TEST_VALID_MODE", testModes, testModeProd))
+	})
+
+	t.Run("invalid: unknown value", func(t *testing.T) {
+		os.Setenv("TEST_INVALID_MODE", "qa")
+		defer os.Unsetenv("TEST_INVALID_MODE")
+
+		assert.Equal(t, testModeProd, GetEnumWithDefault("TEST_INVALID_MODE", testModes, testModeProd))
+	})
+}
@@ -2,119 +2,114 @@ package env
 
 import (
 	"fmt"
-	"os"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/4rchr4y/godevkit/v3/must"
 	"github.com/4rchr4y/godevkit/v3/regex"
 )
 
 func MustGetString(key string) string {
-	value, ok := os.LookupEnv(key)
-	return must.MustBeOk(value, ok, fmt.Errorf("environment variable '%s' was not found", key))
+	return String(key).mustSkip(1)
 }
 
 func GetStringWithDefault(key string, defaultValue string) string {
-	value, ok := os.LookupEnv(key)
-	if !ok {
-		return defaultValue
-	}
-
-	return value
+	return String(key).Default(defaultValue).Get()
 }
 
 func MustGetInt(key string) int {
-	value, err := strconv.Atoi(MustGetString(key))
-	if err != nil {
-		err = fmt.Errorf("invalid environment variable '%s' value: %v", key, err)
-	}
-
-	return must.Must(value, err)
+	return Int(key).mustSkip(1)
 }
 
 func GetIntWithDefault(key string, defaultValue int) int {
-	value, err := strconv.Atoi(os.Getenv(key))
-	if err != nil {
-		return defaultValue
-	}
-
-	return value
+	return Int(key).Default(defaultValue).Get()
 }
 
 func MustGetUint(key string) uint64 {
-	value, err := strconv.ParseUint(MustGetString(key), 10, 64)
-	if err != nil {
-		err = fmt.Errorf("invalid environment variable '%s' value: %v", key, err)
-	}
-
-	return must.Must(value, err)
+	return Uint(key).mustSkip(1)
 }
 
 func GetUintWithDefault(key string, defaultValue uint) uint {
-	value, err := strconv.ParseUint(os.Getenv(key), 10, 64)
-	if err != nil {
-		return defaultValue
-	}
-
-	return uint(value)
+	return uint(Uint(key).Default(uint64(defaultValue)).Get())
 }
 
 func MustGetFloat64(key string) float64 {
-	value, err := strconv.ParseFloat(MustGetString(key), 64)
-	if err != nil {
-		err = fmt.Errorf("invalid environment variable '%s' value: %v", key, err)
-	}
-
-	return must.Must(value, err)
+	return Float64(key).mustSkip(1)
 }
 
 func GetFloat64WithDefault(key string, defaultValue float64) float64 {
-	value, err := strconv.ParseFloat(os.Getenv(key), 64)
-	if err != nil {
-		return defaultValue
-	}
-
-	return value
+	return Float64(key).Default(defaultValue).Get()
 }
 
 func MustGetDuration(key string) time.Duration {
-	value, err := time.ParseDuration(MustGetString(key))
-	if err != nil {
-		err = fmt.Errorf("invalid environment variable '%s' value: %v", key, err)
-	}
-
-	return must.Must(value, err)
+	return Duration(key).mustSkip(1)
 }
 
 func GetDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
-	value, err := time.ParseDuration(os.Getenv(key))
-	if err != nil {
-		return defaultValue
-	}
-
-	return value
+	return Duration(key).Default(defaultValue).Get()
 }
 
 func MustGetUrl(key string) string {
-	value := MustGetString(key)
-	matched, err := regexp.MatchString(regex.UrlPatternString, value)
-	value = must.Must(value, err)
+	return Url(key).mustSkip(1)
+}
 
-	if !matched {
-		panic("env " + key + " with value '" + value + "' is not matching url pattern")
+func GetUrlWithDefault(key string, defaultValue string) string {
+	return Url(key).Default(defaultValue).Get()
+}
+
+// ResolveValue decrypts encrypted values and runs exec values; other
+// values are returned unchanged.
+func ResolveValue(raw string) (string, error) {
+	return resolve(raw)
+}
+
+func resolve(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, EncryptedPrefix):
+		return DecryptValue(value)
+	case strings.HasPrefix(value, ExecPrefix):
+		return ExecValue(value)
+	default:
+		return value, nil
 	}
+}
 
-	return value
+// isSecret reports whether the raw value is resolved from a secret, in
+// which case accesses are always redacted.
+func isSecret(value string) bool {
+	return strings.HasPrefix(value, EncryptedPrefix) || strings.HasPrefix(value, ExecPrefix)
 }
 
-func GetUrlWithDefault(key string, defaultValue string) string {
-	value := os.Getenv(key)
+func parseString(value string) (string, error) {
+	return value, nil
+}
+
+func ParseInt(value string) (int, error) {
+	return strconv.Atoi(value)
+}
+
+func ParseUint(value string) (uint64, error) {
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func ParseFloat64(value string) (float64, error) {
+	return strconv.ParseFloat(value, 64)
+}
+
+func ParseDuration(value string) (time.Duration, error) {
+	return time.ParseDuration(value)
+}
+
+func ParseUrl(value string) (string, error) {
 	matched, err := regexp.MatchString(regex.UrlPatternString, value)
-	if err != nil || !matched {
-		return defaultValue
+	if err != nil {
+		return "", err
+	}
+
+	if !matched {
+		return "", fmt.Errorf("value '%s' is not matching url pattern", value)
 	}
 
-	return value
+	return value, nil
 }
@@ -0,0 +1,164 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/4rchr4y/godevkit/v3/syswrap/execiface"
+)
+
+// ExecPrefix marks values in the form "exec:<command> [args...]" whose
+// value is the standard output of the command. Exec values are disabled
+// until an ExecResolver is installed with SetExecResolver.
+const ExecPrefix = "exec:"
+
+// DefaultExecTimeout is used by NewExecResolver for a timeout <= 0.
+const DefaultExecTimeout = 10 * time.Second
+
+// ExecResolver runs allowlisted commands and caches their output. Outputs
+// are never included in errors and accesses resolved through it are
+// always recorded as sensitive.
+type ExecResolver struct {
+	exec    execiface.ExecWrapper
+	timeout time.Duration
+	allowed map[string]struct{}
+
+	mu    sync.Mutex
+	cache map[string]string
+	calls map[string]*execCall
+}
+
+// execCall is a command in flight; concurrent lookups of the same command
+// wait for it instead of running it again.
+type execCall struct {
+	done  chan struct{}
+	value string
+	err   error
+}
+
+// NewExecResolver allows only the listed programs, matched against the
+// first word of the command. A timeout <= 0 falls back to
+// DefaultExecTimeout.
+func NewExecResolver(exec execiface.ExecWrapper, timeout time.Duration, allowed ...string) *ExecResolver {
+	if timeout <= 0 {
+		timeout = DefaultExecTimeout
+	}
+
+	r := &ExecResolver{
+		exec:    exec,
+		timeout: timeout,
+		allowed: make(map[string]struct{}, len(allowed)),
+		cache:   make(map[string]string),
+		calls:   make(map[string]*execCall),
+	}
+
+	for _, program := range allowed {
+		r.allowed[program] = struct{}{}
+	}
+
+	return r
+}
+
+// Resolve runs command, or returns its cached output. The command is split
+// on whitespace and not passed through a shell. A single trailing newline
+// is trimmed from the output.
+func (r *ExecResolver) Resolve(command string) (string, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return "", errors.New("empty exec command")
+	}
+
+	if _, ok := r.allowed[args[0]]; !ok {
+		return "", fmt.Errorf("exec command '%s' is not allowed", args[0])
+	}
+
+	r.mu.Lock()
+	if output, ok := r.cache[command]; ok {
+		r.mu.Unlock()
+		return output, nil
+	}
+	if call, ok := r.calls[command]; ok {
+		r.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+
+	call := &execCall{done: make(chan struct{})}
+	r.calls[command] = call
+	r.mu.Unlock()
+
+	call.value, call.err = r.run(args)
+
+	r.mu.Lock()
+	// A Reset while the command ran replaced calls, the output is stale.
+	if r.calls[command] == call {
+		delete(r.calls, command)
+		if call.err == nil {
+			r.cache[command] = call.value
+		}
+	}
+	r.mu.Unlock()
+	close(call.done)
+
+	return call.value, call.err
+}
+
+func (r *ExecResolver) run(args []string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	output, err := r.exec.Output(ctx, args[0], args[1:]...)
+	if ctx.Err() != nil {
+		return "", fmt.Errorf("exec command '%s' timed out after %s", args[0], r.timeout)
+	}
+	if err != nil {
+		return "", fmt.Errorf("exec command '%s' failed: %w", args[0], err)
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(string(output), "\n"), "\r"), nil
+}
+
+// Reset drops every cached output.
+func (r *ExecResolver) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cache = make(map[string]string)
+	r.calls = make(map[string]*execCall)
+}
+
+var (
+	execResolverMu sync.RWMutex
+	execResolver   *ExecResolver
+)
+
+// SetExecResolver enables exec values. The returned function restores the
+// previous resolver.
+func SetExecResolver(r *ExecResolver) (restore func()) {
+	execResolverMu.Lock()
+	prev := execResolver
+	execResolver = r
+	execResolverMu.Unlock()
+
+	return func() {
+		execResolverMu.Lock()
+		execResolver = prev
+		execResolverMu.Unlock()
+	}
+}
+
+func ExecValue(value string) (string, error) {
+	execResolverMu.RLock()
+	r := execResolver
+	execResolverMu.RUnlock()
+
+	if r == nil {
+		return "", errors.New("exec values are disabled")
+	}
+
+	return r.Resolve(strings.TrimPrefix(value, ExecPrefix))
+}
@@ -0,0 +1,200 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeExec struct {
+	calls  int
+	output string
+	err    error
+	block  bool
+}
+
+func (f *fakeExec) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	f.calls++
+	if f.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	return []byte(f.output), f.err
+}
+
+// gatedExec blocks commands of the "slow" program until release is closed.
+type gatedExec struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (g *gatedExec) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	g.calls.Add(1)
+	if name == "slow" {
+		g.started <- struct{}{}
+		<-g.release
+	}
+
+	return []byte(name), nil
+}
+
+func TestExecResolver(t *testing.T) {
+	t.Run("valid: output is cached", func(t *testing.T) {
+		fake := &fakeExec{output: "secret\n"}
+		r := NewExecResolver(fake, time.Second, "pass")
+
+		first, err := r.Resolve("pass show db")
+		assert.NoError(t, err)
+		second, err := r.Resolve("pass show db")
+		assert.NoError(t, err)
+
+		assert.Equal(t, "secret", first)
+		assert.Equal(t, "secret", second)
+		assert.Equal(t, 1, fake.calls)
+	})
+
+	t.Run("valid: reset drops the cache", func(t *testing.T) {
+		fake := &fakeExec{output: "secret"}
+		r := NewExecResolver(fake, time.Second, "pass")
+
+		r.Resolve("pass show db")
+		r.Reset()
+		r.Resolve("pass show db")
+
+		assert.Equal(t, 2, fake.calls)
+	})
+
+	t.Run("valid: a slow command does not block other commands", func(t *testing.T) {
+		g := &gatedExec{started: make(chan struct{}), release: make(chan struct{})}
+		r := NewExecResolver(g, time.Second, "slow", "fast")
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			r.Resolve("slow")
+		}()
+		<-g.started
+
+		output, err := r.Resolve("fast")
+		assert.NoError(t, err)
+		assert.Equal(t, "fast", output)
+
+		close(g.release)
+		<-done
+	})
+
+	t.Run("valid: concurrent lookups of a command run it once", func(t *testing.T) {
+		g := &gatedExec{started: make(chan struct{}, 1), release: make(chan struct{})}
+		r := NewExecResolver(g, time.Second, "slow")
+
+		first := make(chan struct{})
+		go func() {
+			defer close(first)
+			r.Resolve("slow")
+		}()
+		<-g.started
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				output, err := r.Resolve("slow")
+				assert.NoError(t, err)
+				assert.Equal(t, "slow", output)
+			}()
+		}
+
+		close(g.release)
+		wg.Wait()
+		<-first
+
+		assert.Equal(t, int32(1), g.calls.Load())
+	})
+
+	t.Run("valid: non-positive timeout falls back to the default", func(t *testing.T) {
+		r := NewExecResolver(&fakeExec{output: "secret"}, 0, "pass")
+
+		output, err := r.Resolve("pass show db")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", output)
+		assert.Equal(t, DefaultExecTimeout, r.timeout)
+	})
+
+	t.Run("invalid: command not in allowlist", func(t *testing.T) {
+		fake := &fakeExec{output: "secret"}
+		r := NewExecResolver(fake, time.Second, "pass")
+
+		_, err := r.Resolve("cat /etc/shadow")
+
+		assert.EqualError(t, err, "exec command 'cat' is not allowed")
+		assert.Zero(t, fake.calls)
+	})
+
+	t.Run("invalid: timeout", func(t *testing.T) {
+		r := NewExecResolver(&fakeExec{block: true}, 10*time.Millisecond, "pass")
+
+		_, err := r.Resolve("pass show db")
+
+		assert.EqualError(t, err, "exec command 'pass' timed out after 10ms")
+	})
+
+	t.Run("invalid: command failure is not cached", func(t *testing.T) {
+		fake := &fakeExec{err: errors.New("exit status 1")}
+		r := NewExecResolver(fake, time.Second, "pass")
+
+		_, err := r.Resolve("pass show db")
+		assert.Error(t, err)
+		_, err = r.Resolve("pass show db")
+		assert.Error(t, err)
+
+		assert.Equal(t, 2, fake.calls)
+	})
+}
+
+func TestExecLookup(t *testing.T) {
+	t.Run("invalid: disabled by default", func(t *testing.T) {
+		defer SetSource(MapSource{"DB_PASSWORD": "exec:pass show db"})()
+
+		_, err := String("DB_PASSWORD").Lookup()
+
+		assert.EqualError(t, err, "invalid environment variable 'DB_PASSWORD' value: exec values are disabled")
+	})
+
+	t.Run("valid: resolved through the installed resolver", func(t *testing.T) {
+		defer SetSource(MapSource{"DB_PASSWORD": "exec:pass show db"})()
+		defer SetExecResolver(NewExecResolver(&fakeExec{output: "hunter2\n"}, time.Second, "pass"))()
+
+		assert.Equal(t, "hunter2", MustGetString("DB_PASSWORD"))
+	})
+
+	t.Run("invalid: output is not included in parse errors", func(t *testing.T) {
+		defer SetSource(MapSource{"DB_PORT": "exec:pass show port"})()
+		defer SetExecResolver(NewExecResolver(&fakeExec{output: "hunter2"}, time.Second, "pass"))()
+
+		_, err := Int("DB_PORT").Lookup()
+		assert.EqualError(t, err, "invalid environment variable 'DB_PORT' value: strconv.Atoi: invalid syntax (value redacted)")
+
+		assert.PanicsWithError(t, err.Error(), func() { MustGetInt("DB_PORT") })
+	})
+
+	t.Run("valid: output is never recorded", func(t *testing.T) {
+		defer SetSource(MapSource{"DB_PASSWORD": "exec:pass show db"})()
+		defer SetExecResolver(NewExecResolver(&fakeExec{output: "hunter2"}, time.Second, "pass"))()
+
+		r := NewMemoryRecorder()
+		defer SetRecorder(r, RedactNone)()
+
+		GetStringWithDefault("DB_PASSWORD", "")
+
+		assert.Equal(t, []Access{{Key: "DB_PASSWORD", Found: true, Sensitive: true, Value: "[REDACTED]"}}, r.Accesses())
+	})
+}
@@ -0,0 +1,141 @@
+package env
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Feature is a single entry of a FeatureSet. Rollout is the percentage of
+// keys the feature is enabled for, 100 unless given as "name:<n>%".
+type Feature struct {
+	Name    string
+	Enabled bool
+	Rollout int
+}
+
+// FeatureSet is parsed from a comma separated list such as
+// "new-ui,fast-path,-legacy,beta:25%", where a leading '-' disables a
+// feature and ":<n>%" enables it for n percent of keys.
+type FeatureSet struct {
+	features map[string]Feature
+}
+
+func ParseFeatures(value string) (FeatureSet, error) {
+	set := FeatureSet{features: make(map[string]Feature)}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		feature, err := parseFeature(entry)
+		if err != nil {
+			return FeatureSet{}, err
+		}
+
+		set.features[feature.Name] = feature
+	}
+
+	return set, nil
+}
+
+func parseFeature(entry string) (Feature, error) {
+	feature := Feature{Enabled: true, Rollout: 100}
+	switch entry[0] {
+	case '-':
+		feature.Enabled, entry = false, entry[1:]
+	case '+':
+		entry = entry[1:]
+	}
+
+	name, rollout, hasRollout := strings.Cut(entry, ":")
+	if name == "" {
+		return Feature{}, fmt.Errorf("empty feature name in '%s'", entry)
+	}
+	feature.Name = name
+
+	if !hasRollout {
+		return feature, nil
+	}
+
+	if !feature.Enabled {
+		return Feature{}, fmt.Errorf("disabled feature '%s' cannot have a rollout", name)
+	}
+
+	percent, err := strconv.Atoi(strings.TrimSuffix(rollout, "%"))
+	if err != nil || !strings.HasSuffix(rollout, "%") || percent < 0 || percent > 100 {
+		return Feature{}, fmt.Errorf("invalid rollout '%s' for feature '%s', expected 0%%..100%%", rollout, name)
+	}
+	feature.Rollout = percent
+
+	return feature, nil
+}
+
+func (s FeatureSet) Lookup(name string) (Feature, bool) {
+	feature, ok := s.features[name]
+	return feature, ok
+}
+
+// Enabled reports whether name is enabled for every key.
+func (s FeatureSet) Enabled(name string) bool {
+	feature, ok := s.features[name]
+	return ok && feature.Enabled && feature.Rollout == 100
+}
+
+// EnabledFor reports whether name is enabled for the key with keyHash. The
+// same keyHash always yields the same result, and different features use
+// independent buckets.
+func (s FeatureSet) EnabledFor(name string, keyHash uint64) bool {
+	feature, ok := s.features[name]
+	if !ok || !feature.Enabled {
+		return false
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(name))
+
+	return int((keyHash^h.Sum64())%100) < feature.Rollout
+}
+
+// Names returns the sorted names of every listed feature.
+func (s FeatureSet) Names() []string {
+	names := make([]string, 0, len(s.features))
+	for name := range s.features {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Unknown returns the sorted names of listed features that are not declared.
+func (s FeatureSet) Unknown(declared ...string) []string {
+	known := make(map[string]struct{}, len(declared))
+	for _, name := range declared {
+		known[name] = struct{}{}
+	}
+
+	var unknown []string
+	for _, name := range s.Names() {
+		if _, ok := known[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+
+	return unknown
+}
+
+func Features(key string) Var[FeatureSet] {
+	return newVar(key, ParseFeatures)
+}
+
+func MustGetFeatures(key string) FeatureSet {
+	return Features(key).mustSkip(1)
+}
+
+func GetFeaturesWithDefault(key string, defaultValue FeatureSet) FeatureSet {
+	return Features(key).Default(defaultValue).Get()
+}
@@ -0,0 +1,112 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFeatures(t *testing.T) {
+	t.Run("valid: markers and rollouts", func(t *testing.T) {
+		set, err := ParseFeatures("new-ui, fast-path,-legacy,+beta:25%,,")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"beta", "fast-path", "legacy", "new-ui"}, set.Names())
+
+		beta, ok := set.Lookup("beta")
+		assert.True(t, ok)
+		assert.Equal(t, Feature{Name: "beta", Enabled: true, Rollout: 25}, beta)
+	})
+
+	t.Run("valid: empty value", func(t *testing.T) {
+		set, err := ParseFeatures("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, set.Names())
+	})
+
+	t.Run("invalid: rollout without percent sign", func(t *testing.T) {
+		_, err := ParseFeatures("beta:25")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid: rollout out of range", func(t *testing.T) {
+		_, err := ParseFeatures("beta:101%")
+
+		assert.EqualError(t, err, "invalid rollout '101%' for feature 'beta', expected 0%..100%")
+	})
+
+	t.Run("invalid: disabled feature with rollout", func(t *testing.T) {
+		_, err := ParseFeatures("-beta:10%")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid: empty name", func(t *testing.T) {
+		_, err := ParseFeatures("-")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFeatureSetEnabled(t *testing.T) {
+	set, err := ParseFeatures("new-ui,-legacy,beta:25%,off:0%")
+	assert.NoError(t, err)
+
+	t.Run("valid: enable and disable markers", func(t *testing.T) {
+		assert.True(t, set.Enabled("new-ui"))
+		assert.False(t, set.Enabled("legacy"))
+		assert.False(t, set.Enabled("missing"))
+		assert.False(t, set.Enabled("beta"))
+	})
+
+	t.Run("valid: rollout is deterministic and proportional", func(t *testing.T) {
+		enabled := 0
+		for key := uint64(0); key < 10000; key++ {
+			result := set.EnabledFor("beta", key)
+			assert.Equal(t, result, set.EnabledFor("beta", key))
+			if result {
+				enabled++
+			}
+		}
+
+		assert.InDelta(t, 2500, enabled, 100)
+	})
+
+	t.Run("valid: full and zero rollouts", func(t *testing.T) {
+		assert.True(t, set.EnabledFor("new-ui", 42))
+		assert.False(t, set.EnabledFor("legacy", 42))
+		assert.False(t, set.EnabledFor("off", 42))
+	})
+}
+
+func TestFeatureSetUnknown(t *testing.T) {
+	set, err := ParseFeatures("new-ui,fast-pth,-legacy")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"fast-pth"}, set.Unknown("new-ui", "fast-path", "legacy"))
+	assert.Empty(t, set.Unknown("new-ui", "fast-pth", "legacy"))
+}
+
+func TestMustGetFeatures(t *testing.T) {
+	t.Run("valid: valid env variable", func(t *testing.T) {
+		defer SetSource(MapSource{"FEATURES": "new-ui,beta:25%"})()
+
+		assert.True(t, MustGetFeatures("FEATURES").Enabled("new-ui"))
+	})
+
+	t.Run("invalid: invalid env value", func(t *testing.T) {
+		defer SetSource(MapSource{"FEATURES": "beta:abc%"})()
+
+		assert.Panics(t, func() { MustGetFeatures("FEATURES") })
+	})
+}
+
+func TestGetFeaturesWithDefault(t *testing.T) {
+	defer SetSource(MapSource{"FEATURES": "beta:abc%"})()
+	defaultSet, err := ParseFeatures("legacy")
+	assert.NoError(t, err)
+
+	assert.True(t, GetFeaturesWithDefault("FEATURES", defaultSet).Enabled("legacy"))
+}
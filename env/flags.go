@@ -0,0 +1,75 @@
+package env
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// FlagEnvName returns the variable bound to a flag, e.g. "APP" and
+// "log-level" give "APP_LOG_LEVEL".
+func FlagEnvName(prefix string, name string) string {
+	if prefix == "" {
+		return UpperSnakeCase(name)
+	}
+
+	return prefix + "_" + UpperSnakeCase(name)
+}
+
+// BindFlags appends the bound variable name to the usage of every flag in
+// fs so that -help shows where the fallback comes from. Flags already
+// showing it are left alone, so calling it again is harmless.
+func BindFlags(fs *flag.FlagSet, prefix string) {
+	fs.VisitAll(func(f *flag.Flag) {
+		suffix := fmt.Sprintf(" (env %s)", FlagEnvName(prefix, f.Name))
+		if !strings.HasSuffix(f.Usage, suffix) {
+			f.Usage += suffix
+		}
+	})
+}
+
+// ParseFlags binds fs to the environment and parses args. Flags passed
+// explicitly win; the remaining ones are set from their variables if those
+// are present.
+func ParseFlags(fs *flag.FlagSet, prefix string, args []string) error {
+	BindFlags(fs, prefix)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return ApplyFlagEnv(fs, prefix)
+}
+
+// ApplyFlagEnv sets every flag of an already parsed fs that was not passed
+// explicitly from its bound variable.
+func ApplyFlagEnv(fs *flag.FlagSet, prefix string) error {
+	passed := make(map[string]struct{})
+	fs.Visit(func(f *flag.Flag) {
+		passed[f.Name] = struct{}{}
+	})
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if _, ok := passed[f.Name]; ok || err != nil {
+			return
+		}
+
+		key := FlagEnvName(prefix, f.Name)
+		raw, ok := currentSource().LookupEnv(key)
+		if !ok {
+			record(Access{Key: key, DefaultUsed: true})
+			return
+		}
+
+		value, resolveErr := resolve(raw)
+		if resolveErr != nil {
+			err = fmt.Errorf("invalid environment variable '%s' value: %w", key, resolveErr)
+		} else if setErr := fs.Set(f.Name, value); setErr != nil {
+			err = InvalidValueError(key, raw, false, setErr)
+		}
+
+		record(Access{Key: key, Found: true, Err: err, Value: value, Sensitive: isSecret(raw)})
+	})
+
+	return err
+}
@@ -0,0 +1,84 @@
+package env
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFlagSet() (*flag.FlagSet, *string, *int, *time.Duration) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	host := fs.String("host", "localhost", "server host")
+	port := fs.Int("port", 8080, "server port")
+	timeout := fs.Duration("read-timeout", time.Second, "read timeout")
+
+	return fs, host, port, timeout
+}
+
+func TestFlagEnvName(t *testing.T) {
+	assert.Equal(t, "APP_READ_TIMEOUT", FlagEnvName("APP", "read-timeout"))
+	assert.Equal(t, "PORT", FlagEnvName("", "port"))
+}
+
+func TestParseFlags(t *testing.T) {
+	t.Run("valid: env provides fallback", func(t *testing.T) {
+		defer SetSource(MapSource{"APP_PORT": "9090", "APP_READ_TIMEOUT": "5s"})()
+		fs, host, port, timeout := newTestFlagSet()
+
+		assert.NoError(t, ParseFlags(fs, "APP", nil))
+		assert.Equal(t, "localhost", *host)
+		assert.Equal(t, 9090, *port)
+		assert.Equal(t, 5*time.Second, *timeout)
+	})
+
+	t.Run("valid: explicit flags win", func(t *testing.T) {
+		defer SetSource(MapSource{"APP_PORT": "9090"})()
+		fs, _, port, _ := newTestFlagSet()
+
+		assert.NoError(t, ParseFlags(fs, "APP", []string{"-port", "7070"}))
+		assert.Equal(t, 7070, *port)
+	})
+
+	t.Run("valid: help shows env names", func(t *testing.T) {
+		var buf bytes.Buffer
+		fs, _, _, _ := newTestFlagSet()
+		fs.SetOutput(&buf)
+
+		assert.ErrorIs(t, ParseFlags(fs, "APP", []string{"-help"}), flag.ErrHelp)
+		assert.Contains(t, buf.String(), "server port (env APP_PORT)")
+	})
+
+	t.Run("valid: binding twice keeps a single env name", func(t *testing.T) {
+		fs, _, _, _ := newTestFlagSet()
+
+		BindFlags(fs, "APP")
+		BindFlags(fs, "APP")
+
+		assert.Equal(t, "server port (env APP_PORT)", fs.Lookup("port").Usage)
+	})
+
+	t.Run("invalid: env value does not parse", func(t *testing.T) {
+		defer SetSource(MapSource{"APP_PORT": "not_an_int"})()
+		fs, _, _, _ := newTestFlagSet()
+
+		assert.ErrorContains(t, ParseFlags(fs, "APP", nil), "invalid environment variable 'APP_PORT' value")
+	})
+
+	t.Run("invalid: exec output is not included in the error", func(t *testing.T) {
+		defer SetSource(MapSource{"APP_LEVEL": "exec:pass show level"})()
+		defer SetExecResolver(NewExecResolver(&fakeExec{output: "hunter2"}, time.Second, "pass"))()
+
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Func("level", "log level", func(value string) error {
+			return fmt.Errorf("unknown level %q", value)
+		})
+
+		err := ParseFlags(fs, "APP", nil)
+
+		assert.EqualError(t, err, "invalid environment variable 'APP_LEVEL' value: invalid value (value redacted)")
+	})
+}
@@ -0,0 +1,116 @@
+package env
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/4rchr4y/godevkit/v3/syswrap/osiface"
+)
+
+// ProfileKey selects the profile when LoadProfile is called without one.
+const ProfileKey = "APP_ENV"
+
+// Profile is the result of layering the dotenv files of an environment
+// profile. Values holds the merged variables, Applied the files that were
+// found and loaded, in the order they were applied.
+type Profile struct {
+	Name    string
+	Applied []string
+	Values  MapSource
+}
+
+// ProfileFiles returns the dotenv files of profile in precedence order,
+// each file overriding the ones before it:
+//
+//	.env
+//	.env.<profile>
+//	.env.local
+//	.env.<profile>.local
+//
+// An empty profile yields only .env and .env.local.
+func ProfileFiles(profile string) []string {
+	if profile == "" {
+		return []string{".env", ".env.local"}
+	}
+
+	return []string{
+		".env",
+		".env." + profile,
+		".env.local",
+		".env." + profile + ".local",
+	}
+}
+
+// validateProfile rejects names that would escape the directory or collide
+// with the .local overrides.
+func validateProfile(profile string) error {
+	switch {
+	case strings.ContainsAny(profile, `/\`):
+		return fmt.Errorf("invalid profile '%s': must not contain a path separator", profile)
+	case strings.Contains(profile, ".."):
+		return fmt.Errorf("invalid profile '%s': must not contain '..'", profile)
+	case profile == "local":
+		return fmt.Errorf("invalid profile '%s': reserved for local overrides", profile)
+	}
+
+	return nil
+}
+
+// LoadProfile layers the dotenv files of profile found in dir, skipping
+// the ones that do not exist. An empty profile is read from ProfileKey.
+func LoadProfile(osw osiface.OSWrapper, dir string, profile string) (*Profile, error) {
+	if profile == "" {
+		profile = GetStringWithDefault(ProfileKey, "")
+	}
+	if err := validateProfile(profile); err != nil {
+		return nil, err
+	}
+
+	p := &Profile{
+		Name:   profile,
+		Values: make(MapSource),
+	}
+
+	for _, name := range ProfileFiles(profile) {
+		path := filepath.Join(dir, name)
+		if !osw.FileExists(path) {
+			continue
+		}
+
+		content, err := osw.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s': %w", path, err)
+		}
+
+		values, err := ParseDotenv(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse '%s': %w", path, err)
+		}
+
+		for key, value := range values {
+			p.Values[key] = value
+		}
+		p.Applied = append(p.Applied, path)
+	}
+
+	return p, nil
+}
+
+// Apply exports the profile values into the process environment. Variables
+// that are already set are kept unless overwrite is true.
+func (p *Profile) Apply(overwrite bool) error {
+	for key, value := range p.Values {
+		if _, ok := os.LookupEnv(key); ok && !overwrite {
+			continue
+		}
+
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to set environment variable '%s': %w", key, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/4rchr4y/godevkit/v3/syswrap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDotenvFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestLoadProfile(t *testing.T) {
+	t.Run("valid: files applied in precedence order", func(t *testing.T) {
+		dir := writeDotenvFiles(t, map[string]string{
+			".env":            "A=base\nB=base\nC=base\nD=base\n",
+			".env.test":       "B=profile\nC=profile\nD=profile\n",
+			".env.local":      "C=local\nD=local\n",
+			".env.test.local": "D=profile-local\n",
+			".env.production": "A=production\n",
+		})
+
+		p, err := LoadProfile(syswrap.OSWrap{}, dir, "test")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test", p.Name)
+		assert.Equal(t, MapSource{"A": "base", "B": "profile", "C": "local", "D": "profile-local"}, p.Values)
+		assert.Equal(t, []string{
+			filepath.Join(dir, ".env"),
+			filepath.Join(dir, ".env.test"),
+			filepath.Join(dir, ".env.local"),
+			filepath.Join(dir, ".env.test.local"),
+		}, p.Applied)
+	})
+
+	t.Run("valid: absent files are skipped", func(t *testing.T) {
+		dir := writeDotenvFiles(t, map[string]string{".env.production": "A=production\n"})
+
+		p, err := LoadProfile(syswrap.OSWrap{}, dir, "production")
+
+		assert.NoError(t, err)
+		assert.Equal(t, MapSource{"A": "production"}, p.Values)
+		assert.Equal(t, []string{filepath.Join(dir, ".env.production")}, p.Applied)
+	})
+
+	t.Run("valid: profile read from APP_ENV", func(t *testing.T) {
+		os.Setenv(ProfileKey, "staging")
+		defer os.Unsetenv(ProfileKey)
+
+		dir := writeDotenvFiles(t, map[string]string{".env.staging": "A=staging\n"})
+
+		p, err := LoadProfile(syswrap.OSWrap{}, dir, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "staging", p.Name)
+		assert.Equal(t, MapSource{"A": "staging"}, p.Values)
+	})
+
+	t.Run("invalid: malformed file", func(t *testing.T) {
+		dir := writeDotenvFiles(t, map[string]string{".env": "INVALID\n"})
+
+		_, err := LoadProfile(syswrap.OSWrap{}, dir, "")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid: profile names", func(t *testing.T) {
+		dir := writeDotenvFiles(t, map[string]string{".env": "A=base\n"})
+
+		for _, profile := range []string{"local", "../secrets", `..\secrets`, "a..b"} {
+			_, err := LoadProfile(syswrap.OSWrap{}, dir, profile)
+
+			assert.ErrorContains(t, err, "invalid profile", profile)
+		}
+	})
+}
+
+func TestProfileApply(t *testing.T) {
+	t.Run("valid: existing variables are kept", func(t *testing.T) {
+		os.Setenv("TEST_PROFILE_EXISTING", "process")
+		defer os.Unsetenv("TEST_PROFILE_EXISTING")
+		defer os.Unsetenv("TEST_PROFILE_NEW")
+
+		p := &Profile{Values: MapSource{"TEST_PROFILE_EXISTING": "file", "TEST_PROFILE_NEW": "file"}}
+
+		assert.NoError(t, p.Apply(false))
+		assert.Equal(t, "process", os.Getenv("TEST_PROFILE_EXISTING"))
+		assert.Equal(t, "file", os.Getenv("TEST_PROFILE_NEW"))
+	})
+
+	t.Run("valid: overwrite replaces existing variables", func(t *testing.T) {
+		os.Setenv("TEST_PROFILE_EXISTING", "process")
+		defer os.Unsetenv("TEST_PROFILE_EXISTING")
+
+		p := &Profile{Values: MapSource{"TEST_PROFILE_EXISTING": "file"}}
+
+		assert.NoError(t, p.Apply(true))
+		assert.Equal(t, "file", os.Getenv("TEST_PROFILE_EXISTING"))
+	})
+}
@@ -0,0 +1,240 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Access describes a single environment variable lookup. Value is
+// already passed through the redact function of the active recorder, or
+// through RedactAll if the variable is marked sensitive. When Value is
+// redacted, Err is replaced by a *RedactedError, since parse errors usually
+// quote the value.
+type Access struct {
+	Key         string
+	Found       bool
+	DefaultUsed bool
+	Sensitive   bool
+	Err         error
+	Value       string
+}
+
+type Recorder interface {
+	Record(access Access)
+}
+
+type RedactFunc func(key string, value string) string
+
+func RedactAll(key string, value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return "[REDACTED]"
+}
+
+func RedactNone(key string, value string) string {
+	return value
+}
+
+var (
+	recorderMu sync.RWMutex
+	recorder   Recorder
+	redact     RedactFunc = RedactAll
+)
+
+// SetRecorder enables recording of every lookup made by the package getters.
+// A nil redact function falls back to RedactAll. The returned function
+// restores the previously installed recorder.
+func SetRecorder(r Recorder, redactFn RedactFunc) (restore func()) {
+	if redactFn == nil {
+		redactFn = RedactAll
+	}
+
+	recorderMu.Lock()
+	prevRecorder, prevRedact := recorder, redact
+	recorder, redact = r, redactFn
+	recorderMu.Unlock()
+
+	return func() {
+		recorderMu.Lock()
+		recorder, redact = prevRecorder, prevRedact
+		recorderMu.Unlock()
+	}
+}
+
+func record(access Access) {
+	recorderMu.RLock()
+	r, redactFn := recorder, redact
+	recorderMu.RUnlock()
+
+	if r == nil {
+		return
+	}
+
+	if access.Sensitive {
+		redactFn = RedactAll
+	}
+
+	if access.Found {
+		redacted := redactFn(access.Key, access.Value)
+		if access.Err != nil && redacted != access.Value {
+			access.Err = redactError(access.Key, access.Err)
+		}
+		access.Value = redacted
+	}
+
+	r.Record(access)
+}
+
+// RedactedError stands in for an error about a value that must not be
+// shown. Kind describes the failure without the value.
+type RedactedError struct {
+	Key  string
+	Kind string
+}
+
+func (e *RedactedError) Error() string {
+	return fmt.Sprintf("invalid environment variable '%s' value: %s (value redacted)", e.Key, e.Kind)
+}
+
+func redactError(key string, err error) error {
+	var redacted *RedactedError
+	if errors.As(err, &redacted) {
+		return err
+	}
+
+	kind := "invalid value"
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		kind = "strconv." + numErr.Func + ": " + numErr.Err.Error()
+	}
+
+	return &RedactedError{Key: key, Kind: kind}
+}
+
+type MemoryRecorder struct {
+	mu       sync.Mutex
+	accesses []Access
+}
+
+func NewMemoryRecorder() *MemoryRecorder {
+	return &MemoryRecorder{}
+}
+
+func (r *MemoryRecorder) Record(access Access) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.accesses = append(r.accesses, access)
+}
+
+func (r *MemoryRecorder) Accesses() []Access {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	accesses := make([]Access, len(r.accesses))
+	copy(accesses, r.accesses)
+
+	return accesses
+}
+
+// Keys returns the sorted set of keys that were read at least once.
+func (r *MemoryRecorder) Keys() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[string]struct{}, len(r.accesses))
+	keys := make([]string, 0, len(r.accesses))
+	for _, access := range r.accesses {
+		if _, ok := seen[access.Key]; ok {
+			continue
+		}
+
+		seen[access.Key] = struct{}{}
+		keys = append(keys, access.Key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// WriteSummary writes one line per key with the outcome of its last lookup.
+func (r *MemoryRecorder) WriteSummary(w io.Writer) error {
+	r.mu.Lock()
+	last := make(map[string]Access, len(r.accesses))
+	for _, access := range r.accesses {
+		last[access.Key] = access
+	}
+	r.mu.Unlock()
+
+	keys := make([]string, 0, len(last))
+	for key := range last {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, err := fmt.Fprintln(w, formatAccess(last[key])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func formatAccess(access Access) string {
+	status := "not found"
+	if access.Found {
+		status = "found"
+	}
+
+	line := fmt.Sprintf("%s: %s", access.Key, status)
+	if access.Found {
+		line += fmt.Sprintf(", value '%s'", access.Value)
+	}
+	if access.DefaultUsed {
+		line += ", default used"
+	}
+	if access.Err != nil {
+		line += fmt.Sprintf(", error: %v", access.Err)
+	}
+
+	return line
+}
+
+type SlogRecorder struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+func NewSlogRecorder(handler slog.Handler, level slog.Level) *SlogRecorder {
+	return &SlogRecorder{
+		logger: slog.New(handler),
+		level:  level,
+	}
+}
+
+func (r *SlogRecorder) Record(access Access) {
+	attrs := []slog.Attr{
+		slog.String("key", access.Key),
+		slog.Bool("found", access.Found),
+		slog.Bool("default_used", access.DefaultUsed),
+	}
+
+	if access.Found {
+		attrs = append(attrs, slog.String("value", access.Value))
+	}
+
+	if access.Err != nil {
+		attrs = append(attrs, slog.String("error", access.Err.Error()))
+	}
+
+	r.logger.LogAttrs(context.Background(), r.level, "environment variable read", attrs...)
+}
@@ -0,0 +1,136 @@
+package env
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetRecorder(t *testing.T) {
+	t.Run("valid: records found value with redaction", func(t *testing.T) {
+		os.Setenv("TEST_RECORDED_STRING", "secret")
+		defer os.Unsetenv("TEST_RECORDED_STRING")
+
+		r := NewMemoryRecorder()
+		defer SetRecorder(r, nil)()
+
+		GetStringWithDefault("TEST_RECORDED_STRING", "default")
+
+		assert.Equal(t, []Access{{Key: "TEST_RECORDED_STRING", Found: true, Value: "[REDACTED]"}}, r.Accesses())
+	})
+
+	t.Run("valid: records missing key and default usage", func(t *testing.T) {
+		r := NewMemoryRecorder()
+		defer SetRecorder(r, RedactNone)()
+
+		GetIntWithDefault("TEST_RECORDED_MISSING", 10)
+
+		assert.Equal(t, []Access{{Key: "TEST_RECORDED_MISSING", DefaultUsed: true}}, r.Accesses())
+	})
+
+	t.Run("valid: records parse error", func(t *testing.T) {
+		os.Setenv("TEST_RECORDED_INT", "not_an_int")
+		defer os.Unsetenv("TEST_RECORDED_INT")
+
+		r := NewMemoryRecorder()
+		defer SetRecorder(r, RedactNone)()
+
+		GetIntWithDefault("TEST_RECORDED_INT", 10)
+
+		accesses := r.Accesses()
+		assert.Len(t, accesses, 1)
+		assert.True(t, accesses[0].Found)
+		assert.True(t, accesses[0].DefaultUsed)
+		assert.Equal(t, "not_an_int", accesses[0].Value)
+		assert.Error(t, accesses[0].Err)
+	})
+
+	t.Run("valid: redacted values are not leaked through errors", func(t *testing.T) {
+		os.Setenv("TEST_RECORDED_PORT", "hunter2")
+		defer os.Unsetenv("TEST_RECORDED_PORT")
+
+		for _, tc := range []struct {
+			name   string
+			redact RedactFunc
+			v      Var[int]
+		}{
+			{name: "sensitive", redact: RedactNone, v: Int("TEST_RECORDED_PORT").Sensitive()},
+			{name: "redact all", redact: RedactAll, v: Int("TEST_RECORDED_PORT")},
+		} {
+			r := NewMemoryRecorder()
+			var buf bytes.Buffer
+			restore := SetRecorder(multiRecorder{r, NewSlogRecorder(slog.NewTextHandler(&buf, nil), slog.LevelInfo)}, tc.redact)
+
+			tc.v.Get()
+			restore()
+
+			accesses := r.Accesses()
+			assert.Len(t, accesses, 1, tc.name)
+			assert.NotContains(t, accesses[0].Value, "hunter2", tc.name)
+			assert.EqualError(t, accesses[0].Err, "invalid environment variable 'TEST_RECORDED_PORT' value: strconv.Atoi: invalid syntax (value redacted)", tc.name)
+
+			assert.NoError(t, r.WriteSummary(&buf))
+			assert.NotContains(t, buf.String(), "hunter2", tc.name)
+		}
+	})
+
+	t.Run("valid: records lookup before must panic", func(t *testing.T) {
+		r := NewMemoryRecorder()
+		defer SetRecorder(r, nil)()
+
+		assert.Panics(t, func() { MustGetString("TEST_RECORDED_MISSING") })
+		assert.Equal(t, []string{"TEST_RECORDED_MISSING"}, r.Keys())
+	})
+
+	t.Run("valid: restore disables recording", func(t *testing.T) {
+		r := NewMemoryRecorder()
+		SetRecorder(r, nil)()
+
+		GetStringWithDefault("TEST_RECORDED_MISSING", "default")
+
+		assert.Empty(t, r.Accesses())
+	})
+}
+
+func TestMemoryRecorderWriteSummary(t *testing.T) {
+	t.Run("valid: one line per key", func(t *testing.T) {
+		os.Setenv("TEST_SUMMARY_INT", "10")
+		defer os.Unsetenv("TEST_SUMMARY_INT")
+
+		r := NewMemoryRecorder()
+		defer SetRecorder(r, RedactNone)()
+
+		GetIntWithDefault("TEST_SUMMARY_INT", 5)
+		GetIntWithDefault("TEST_SUMMARY_INT", 5)
+		GetStringWithDefault("TEST_SUMMARY_MISSING", "default")
+
+		var buf bytes.Buffer
+		assert.NoError(t, r.WriteSummary(&buf))
+		assert.Equal(t, "TEST_SUMMARY_INT: found, value '10'\nTEST_SUMMARY_MISSING: not found, default used\n", buf.String())
+	})
+}
+
+func TestSlogRecorder(t *testing.T) {
+	t.Run("valid: logs access attributes", func(t *testing.T) {
+		os.Setenv("TEST_SLOG_STRING", "secret")
+		defer os.Unsetenv("TEST_SLOG_STRING")
+
+		var buf bytes.Buffer
+		defer SetRecorder(NewSlogRecorder(slog.NewTextHandler(&buf, nil), slog.LevelInfo), nil)()
+
+		GetStringWithDefault("TEST_SLOG_STRING", "default")
+
+		assert.Contains(t, buf.String(), "key=TEST_SLOG_STRING found=true default_used=false value=[REDACTED]")
+	})
+}
+
+type multiRecorder []Recorder
+
+func (m multiRecorder) Record(access Access) {
+	for _, r := range m {
+		r.Record(access)
+	}
+}
@@ -0,0 +1,163 @@
+package env
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type snapshotEntry struct {
+	raw    string
+	secret bool
+
+	intValue      int
+	intErr        error
+	uintValue     uint64
+	uintErr       error
+	floatValue    float64
+	floatErr      error
+	durationValue time.Duration
+	durationErr   error
+}
+
+// Snapshot is an immutable copy of a Source with every plain value parsed
+// up front, so lookups neither lock nor allocate. Encrypted and exec values
+// are kept unresolved: LookupEnv returns them as captured, Environ leaves
+// them out, and the getters resolve them on each call like a Var does, so
+// their accesses are recorded and redacted. It is safe for concurrent use.
+type Snapshot struct {
+	entries map[string]*snapshotEntry
+}
+
+// NewSnapshot captures src. Encrypted and exec values are not resolved, so
+// capturing never decrypts or runs a command; a value that fails to resolve
+// is reported by the lookup that reads it.
+func NewSnapshot(src Source) *Snapshot {
+	environ := src.Environ()
+	entries := make(map[string]*snapshotEntry, len(environ))
+	for _, kv := range environ {
+		key, raw, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+
+		if isSecret(raw) {
+			entries[key] = &snapshotEntry{raw: raw, secret: true}
+			continue
+		}
+
+		entry := &snapshotEntry{raw: raw}
+		entry.intValue, entry.intErr = ParseInt(raw)
+		entry.uintValue, entry.uintErr = ParseUint(raw)
+		entry.floatValue, entry.floatErr = ParseFloat64(raw)
+		entry.durationValue, entry.durationErr = ParseDuration(raw)
+		entries[key] = entry
+	}
+
+	return &Snapshot{entries: entries}
+}
+
+func (s *Snapshot) Len() int {
+	return len(s.entries)
+}
+
+func (s *Snapshot) LookupEnv(key string) (string, bool) {
+	entry, ok := s.entries[key]
+	if !ok {
+		return "", false
+	}
+
+	return entry.raw, true
+}
+
+// Environ returns the plain entries; encrypted and exec values are left out.
+func (s *Snapshot) Environ() []string {
+	environ := make([]string, 0, len(s.entries))
+	for key, entry := range s.entries {
+		if entry.secret {
+			continue
+		}
+
+		environ = append(environ, key+"="+entry.raw)
+	}
+
+	return environ
+}
+
+func (s *Snapshot) GetStringWithDefault(key string, defaultValue string) string {
+	if entry, ok := s.entries[key]; ok {
+		if entry.secret {
+			return String(key).From(s).Default(defaultValue).Get()
+		}
+
+		return entry.raw
+	}
+
+	return defaultValue
+}
+
+func (s *Snapshot) GetIntWithDefault(key string, defaultValue int) int {
+	if entry, ok := s.entries[key]; ok && entry.secret {
+		return Int(key).From(s).Default(defaultValue).Get()
+	} else if ok && entry.intErr == nil {
+		return entry.intValue
+	}
+
+	return defaultValue
+}
+
+func (s *Snapshot) GetUintWithDefault(key string, defaultValue uint) uint {
+	if entry, ok := s.entries[key]; ok && entry.secret {
+		return uint(Uint(key).From(s).Default(uint64(defaultValue)).Get())
+	} else if ok && entry.uintErr == nil {
+		return uint(entry.uintValue)
+	}
+
+	return defaultValue
+}
+
+func (s *Snapshot) GetFloat64WithDefault(key string, defaultValue float64) float64 {
+	if entry, ok := s.entries[key]; ok && entry.secret {
+		return Float64(key).From(s).Default(defaultValue).Get()
+	} else if ok && entry.floatErr == nil {
+		return entry.floatValue
+	}
+
+	return defaultValue
+}
+
+func (s *Snapshot) GetDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
+	if entry, ok := s.entries[key]; ok && entry.secret {
+		return Duration(key).From(s).Default(defaultValue).Get()
+	} else if ok && entry.durationErr == nil {
+		return entry.durationValue
+	}
+
+	return defaultValue
+}
+
+// AtomicSnapshot holds the current Snapshot and lets a reload swap it
+// without blocking readers.
+type AtomicSnapshot struct {
+	current atomic.Pointer[Snapshot]
+}
+
+func NewAtomicSnapshot(src Source) *AtomicSnapshot {
+	s := &AtomicSnapshot{}
+	s.current.Store(NewSnapshot(src))
+
+	return s
+}
+
+func (s *AtomicSnapshot) Load() *Snapshot {
+	return s.current.Load()
+}
+
+func (s *AtomicSnapshot) Store(snapshot *Snapshot) {
+	s.current.Store(snapshot)
+}
+
+// Reload captures src and publishes it, returning the previous snapshot.
+func (s *AtomicSnapshot) Reload(src Source) *Snapshot {
+	return s.current.Swap(NewSnapshot(src))
+}
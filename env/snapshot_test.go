@@ -0,0 +1,139 @@
+package env
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	snapshot := NewSnapshot(MapSource{
+		"TEST_STRING":   "value",
+		"TEST_INT":      "10",
+		"TEST_FLOAT":    "1.5",
+		"TEST_DURATION": "1h",
+	})
+
+	t.Run("valid: pre-parsed values", func(t *testing.T) {
+		assert.Equal(t, "value", snapshot.GetStringWithDefault("TEST_STRING", "default"))
+		assert.Equal(t, 10, snapshot.GetIntWithDefault("TEST_INT", 5))
+		assert.Equal(t, uint(10), snapshot.GetUintWithDefault("TEST_INT", 5))
+		assert.Equal(t, 1.5, snapshot.GetFloat64WithDefault("TEST_FLOAT", 0))
+		assert.Equal(t, time.Hour, snapshot.GetDurationWithDefault("TEST_DURATION", time.Second))
+	})
+
+	t.Run("invalid: unparsable value falls back to default", func(t *testing.T) {
+		assert.Equal(t, 5, snapshot.GetIntWithDefault("TEST_STRING", 5))
+		assert.Equal(t, time.Second, snapshot.GetDurationWithDefault("TEST_INT", time.Second))
+	})
+
+	t.Run("invalid: missing key falls back to default", func(t *testing.T) {
+		assert.Equal(t, "default", snapshot.GetStringWithDefault("TEST_MISSING", "default"))
+		assert.Equal(t, 5, snapshot.GetIntWithDefault("TEST_MISSING", 5))
+	})
+
+	t.Run("valid: lookups do not allocate", func(t *testing.T) {
+		allocs := testing.AllocsPerRun(100, func() {
+			snapshot.GetIntWithDefault("TEST_INT", 5)
+			snapshot.GetStringWithDefault("TEST_STRING", "default")
+		})
+
+		assert.Zero(t, allocs)
+	})
+}
+
+func TestSnapshotSecrets(t *testing.T) {
+	aesgcm := newTestAESGCM(t)
+	defer RegisterDecrypter("v1", aesgcm)()
+
+	encrypted, err := EncryptValue("v1", aesgcm, "8080")
+	require.NoError(t, err)
+
+	exec := &fakeExec{output: "hunter2"}
+	defer SetExecResolver(NewExecResolver(exec, time.Second, "pass"))()
+
+	snapshot := NewSnapshot(MapSource{
+		"TEST_PORT":     encrypted,
+		"TEST_PASSWORD": "exec:pass show db",
+		"TEST_BROKEN":   "enc:v9:AAAA",
+		"TEST_PLAIN":    "value",
+	})
+
+	t.Run("valid: capture does not resolve secrets", func(t *testing.T) {
+		assert.Zero(t, exec.calls)
+	})
+
+	t.Run("valid: environ leaves secrets out", func(t *testing.T) {
+		assert.Equal(t, []string{"TEST_PLAIN=value"}, snapshot.Environ())
+
+		value, ok := snapshot.LookupEnv("TEST_PORT")
+		assert.True(t, ok)
+		assert.Equal(t, encrypted, value)
+	})
+
+	t.Run("valid: getters resolve and redact secrets", func(t *testing.T) {
+		r := NewMemoryRecorder()
+		defer SetRecorder(r, RedactNone)()
+
+		assert.Equal(t, 8080, snapshot.GetIntWithDefault("TEST_PORT", 0))
+		assert.Equal(t, "hunter2", snapshot.GetStringWithDefault("TEST_PASSWORD", ""))
+		assert.Equal(t, []Access{
+			{Key: "TEST_PORT", Found: true, Sensitive: true, Value: "[REDACTED]"},
+			{Key: "TEST_PASSWORD", Found: true, Sensitive: true, Value: "[REDACTED]"},
+		}, r.Accesses())
+	})
+
+	t.Run("invalid: resolution failure is reported", func(t *testing.T) {
+		r := NewMemoryRecorder()
+		defer SetRecorder(r, RedactNone)()
+
+		assert.Equal(t, "default", snapshot.GetStringWithDefault("TEST_BROKEN", "default"))
+		require.Len(t, r.Accesses(), 1)
+		assert.Error(t, r.Accesses()[0].Err)
+
+		_, err := String("TEST_BROKEN").From(snapshot).Lookup()
+		assert.EqualError(t, err, "invalid environment variable 'TEST_BROKEN' value: no decrypter registered for version 'v9'")
+	})
+}
+
+func TestAtomicSnapshot(t *testing.T) {
+	t.Run("valid: reload swaps snapshot", func(t *testing.T) {
+		s := NewAtomicSnapshot(MapSource{"TEST_INT": "1"})
+
+		prev := s.Reload(MapSource{"TEST_INT": "2"})
+
+		assert.Equal(t, 1, prev.GetIntWithDefault("TEST_INT", 0))
+		assert.Equal(t, 2, s.Load().GetIntWithDefault("TEST_INT", 0))
+	})
+
+	t.Run("valid: concurrent readers during reload", func(t *testing.T) {
+		s := NewAtomicSnapshot(MapSource{"TEST_INT": "1"})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 1000; j++ {
+					v := s.Load().GetIntWithDefault("TEST_INT", 0)
+					assert.Contains(t, []int{1, 2}, v)
+				}
+			}()
+		}
+
+		s.Reload(MapSource{"TEST_INT": "2"})
+		wg.Wait()
+	})
+}
+
+func BenchmarkSnapshotGetIntWithDefault(b *testing.B) {
+	snapshot := NewSnapshot(MapSource{"TEST_INT": "10"})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		snapshot.GetIntWithDefault("TEST_INT", 5)
+	}
+}
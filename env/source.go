@@ -0,0 +1,103 @@
+package env
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Source provides environment variables to the package helpers.
+type Source interface {
+	LookupEnv(key string) (string, bool)
+	Environ() []string
+}
+
+var (
+	sourceMu sync.RWMutex
+	source   Source = ProcessSource{}
+)
+
+// SetSource replaces the source read by the package getters, which is the
+// process environment by default. The returned function restores the
+// previous source.
+func SetSource(src Source) (restore func()) {
+	sourceMu.Lock()
+	prev := source
+	source = src
+	sourceMu.Unlock()
+
+	return func() {
+		sourceMu.Lock()
+		source = prev
+		sourceMu.Unlock()
+	}
+}
+
+func currentSource() Source {
+	sourceMu.RLock()
+	defer sourceMu.RUnlock()
+
+	return source
+}
+
+// ProcessSource reads the environment of the current process.
+type ProcessSource struct{}
+
+func (ProcessSource) LookupEnv(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+func (ProcessSource) Environ() []string {
+	return os.Environ()
+}
+
+// MapSource serves variables from a fixed map.
+type MapSource map[string]string
+
+func (s MapSource) LookupEnv(key string) (string, bool) {
+	value, ok := s[key]
+	return value, ok
+}
+
+func (s MapSource) Environ() []string {
+	environ := make([]string, 0, len(s))
+	for key, value := range s {
+		environ = append(environ, key+"="+value)
+	}
+
+	sort.Strings(environ)
+	return environ
+}
+
+// ChainSource looks variables up in each source in order and returns the
+// first match.
+type ChainSource []Source
+
+func (s ChainSource) LookupEnv(key string) (string, bool) {
+	for _, src := range s {
+		if value, ok := src.LookupEnv(key); ok {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+func (s ChainSource) Environ() []string {
+	seen := make(map[string]struct{})
+	var environ []string
+	for _, src := range s {
+		for _, kv := range src.Environ() {
+			key, _, _ := strings.Cut(kv, "=")
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			environ = append(environ, kv)
+		}
+	}
+
+	return environ
+}
@@ -0,0 +1,57 @@
+package env
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainSource(t *testing.T) {
+	src := ChainSource{
+		MapSource{"A": "first", "B": "first"},
+		MapSource{"B": "second", "C": "second"},
+	}
+
+	t.Run("valid: first match wins", func(t *testing.T) {
+		value, ok := src.LookupEnv("B")
+
+		assert.True(t, ok)
+		assert.Equal(t, "first", value)
+	})
+
+	t.Run("valid: falls through to later sources", func(t *testing.T) {
+		value, ok := src.LookupEnv("C")
+
+		assert.True(t, ok)
+		assert.Equal(t, "second", value)
+	})
+
+	t.Run("invalid: missing key", func(t *testing.T) {
+		_, ok := src.LookupEnv("D")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("valid: environ without shadowed keys", func(t *testing.T) {
+		assert.Equal(t, []string{"A=first", "B=first", "C=second"}, src.Environ())
+	})
+}
+
+func TestSetSource(t *testing.T) {
+	t.Run("valid: typed getters read the installed source", func(t *testing.T) {
+		os.Setenv("TEST_SOURCE_PORT", "1")
+		defer os.Unsetenv("TEST_SOURCE_PORT")
+
+		defer SetSource(ChainSource{ProcessSource{}, MapSource{"TEST_SOURCE_PORT": "2", "TEST_SOURCE_HOST": "localhost"}})()
+
+		assert.Equal(t, 1, MustGetInt("TEST_SOURCE_PORT"))
+		assert.Equal(t, "localhost", MustGetString("TEST_SOURCE_HOST"))
+	})
+
+	t.Run("valid: restore brings back the process environment", func(t *testing.T) {
+		SetSource(MapSource{"TEST_SOURCE_HOST": "localhost"})()
+
+		assert.Equal(t, "default", GetStringWithDefault("TEST_SOURCE_HOST", "default"))
+	})
+}
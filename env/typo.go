@@ -0,0 +1,134 @@
+package env
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+const maxSuggestions = 3
+
+// UnknownKey is a variable found under a prefix that the application does
+// not declare, along with the declared keys it most likely meant.
+type UnknownKey struct {
+	Key         string
+	Suggestions []string
+}
+
+func (k UnknownKey) String() string {
+	if len(k.Suggestions) == 0 {
+		return k.Key
+	}
+
+	return fmt.Sprintf("%s (did you mean %s?)", k.Key, strings.Join(k.Suggestions, ", "))
+}
+
+type UnknownKeysError struct {
+	Prefix string
+	Keys   []UnknownKey
+}
+
+func (e *UnknownKeysError) Error() string {
+	keys := make([]string, len(e.Keys))
+	for i, key := range e.Keys {
+		keys[i] = key.String()
+	}
+
+	return fmt.Sprintf("unknown environment variables with prefix '%s': %s", e.Prefix, strings.Join(keys, "; "))
+}
+
+// FindUnknownKeys scans src for keys starting with prefix that are not in
+// declared and suggests the closest declared keys by edit distance.
+func FindUnknownKeys(src Source, prefix string, declared []string) []UnknownKey {
+	known := make(map[string]struct{}, len(declared))
+	for _, key := range declared {
+		known[key] = struct{}{}
+	}
+
+	var unknown []UnknownKey
+	for _, kv := range src.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		if _, ok := known[key]; ok {
+			continue
+		}
+
+		unknown = append(unknown, UnknownKey{Key: key, Suggestions: suggest(key, declared)})
+	}
+
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Key < unknown[j].Key })
+	return unknown
+}
+
+// CheckUnknownKeys returns an *UnknownKeysError if src has unknown keys
+// under prefix.
+func CheckUnknownKeys(src Source, prefix string, declared []string) error {
+	unknown := FindUnknownKeys(src, prefix, declared)
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	return &UnknownKeysError{Prefix: prefix, Keys: unknown}
+}
+
+// WarnUnknownKeys logs a warning for every unknown key under prefix.
+func WarnUnknownKeys(logger *slog.Logger, src Source, prefix string, declared []string) {
+	for _, key := range FindUnknownKeys(src, prefix, declared) {
+		logger.Warn("unknown environment variable", slog.String("key", key.Key), slog.Any("suggestions", key.Suggestions))
+	}
+}
+
+func suggest(key string, declared []string) []string {
+	type candidate struct {
+		key      string
+		distance int
+	}
+
+	threshold := max(2, len(key)/4)
+	candidates := make([]candidate, 0, len(declared))
+	for _, d := range declared {
+		if distance := levenshtein(key, d); distance <= threshold {
+			candidates = append(candidates, candidate{key: d, distance: distance})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].key < candidates[j].key
+	})
+
+	suggestions := make([]string, 0, min(len(candidates), maxSuggestions))
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].key)
+	}
+
+	return suggestions
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
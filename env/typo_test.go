@@ -0,0 +1,65 @@
+package env
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testDeclaredKeys = []string{"APP_DATABASE_URL", "APP_PORT", "APP_LOG_LEVEL"}
+
+func TestFindUnknownKeys(t *testing.T) {
+	t.Run("valid: no unknown keys", func(t *testing.T) {
+		src := MapSource{"APP_PORT": "8080", "HOME": "/root"}
+
+		assert.Empty(t, FindUnknownKeys(src, "APP_", testDeclaredKeys))
+	})
+
+	t.Run("valid: typo with suggestion", func(t *testing.T) {
+		src := MapSource{"APP_DATABSE_URL": "postgres://", "APP_PORT": "8080"}
+
+		assert.Equal(t, []UnknownKey{
+			{Key: "APP_DATABSE_URL", Suggestions: []string{"APP_DATABASE_URL"}},
+		}, FindUnknownKeys(src, "APP_", testDeclaredKeys))
+	})
+
+	t.Run("valid: unrelated key without suggestion", func(t *testing.T) {
+		src := MapSource{"APP_SOMETHING_ELSE": "1"}
+
+		assert.Equal(t, []UnknownKey{
+			{Key: "APP_SOMETHING_ELSE", Suggestions: []string{}},
+		}, FindUnknownKeys(src, "APP_", testDeclaredKeys))
+	})
+}
+
+func TestCheckUnknownKeys(t *testing.T) {
+	t.Run("valid: nil error when all keys are declared", func(t *testing.T) {
+		assert.NoError(t, CheckUnknownKeys(MapSource{"APP_PORT": "8080"}, "APP_", testDeclaredKeys))
+	})
+
+	t.Run("invalid: unknown key", func(t *testing.T) {
+		err := CheckUnknownKeys(MapSource{"APP_PROT": "8080"}, "APP_", testDeclaredKeys)
+
+		assert.EqualError(t, err, "unknown environment variables with prefix 'APP_': APP_PROT (did you mean APP_PORT?)")
+	})
+}
+
+func TestWarnUnknownKeys(t *testing.T) {
+	t.Run("valid: logs warning", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+		WarnUnknownKeys(logger, MapSource{"APP_PROT": "8080"}, "APP_", testDeclaredKeys)
+
+		assert.Contains(t, buf.String(), "level=WARN msg=\"unknown environment variable\" key=APP_PROT suggestions=[APP_PORT]")
+	})
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("abc", "abc"))
+	assert.Equal(t, 2, levenshtein("APP_PROT", "APP_PORT"))
+	assert.Equal(t, 3, levenshtein("", "abc"))
+	assert.Equal(t, 1, levenshtein("APP_DATABSE_URL", "APP_DATABASE_URL"))
+}
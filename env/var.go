@@ -0,0 +1,212 @@
+package env
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+
+	"github.com/4rchr4y/godevkit/v3/internal/check"
+	// must installs check.Fail, so failures are raised as *must.Error.
+	_ "github.com/4rchr4y/godevkit/v3/must"
+)
+
+type NotFoundError struct {
+	Key string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("environment variable '%s' was not found", e.Key)
+}
+
+// Var describes a typed environment variable. Every method returns a
+// modified copy, so a Var can be shared and extended safely:
+//
+//	port := env.Int("PORT").Default(8080).Validate(env.InRange(1, 65535)).Alias("PORT_OLD").Get()
+type Var[T any] struct {
+	key        string
+	aliases    []string
+	parse      func(string) (T, error)
+	validators []func(T) error
+	def        T
+	hasDefault bool
+	sensitive  bool
+	src        Source
+}
+
+func newVar[T any](key string, parse func(string) (T, error)) Var[T] {
+	return Var[T]{key: key, parse: parse}
+}
+
+func String(key string) Var[string] {
+	return newVar(key, parseString)
+}
+
+func Int(key string) Var[int] {
+	return newVar(key, ParseInt)
+}
+
+func Uint(key string) Var[uint64] {
+	return newVar(key, ParseUint)
+}
+
+func Float64(key string) Var[float64] {
+	return newVar(key, ParseFloat64)
+}
+
+func Duration(key string) Var[time.Duration] {
+	return newVar(key, ParseDuration)
+}
+
+func Url(key string) Var[string] {
+	return newVar(key, ParseUrl)
+}
+
+func EnumVar[T any](key string, enum Enum[T]) Var[T] {
+	return newVar(key, enum.Parse)
+}
+
+func (v Var[T]) Key() string {
+	return v.key
+}
+
+// Default makes the variable optional.
+func (v Var[T]) Default(value T) Var[T] {
+	v.def, v.hasDefault = value, true
+	return v
+}
+
+// Alias adds keys that are looked up, in order, when the primary key is
+// not set.
+func (v Var[T]) Alias(keys ...string) Var[T] {
+	v.aliases = append(v.aliases[:len(v.aliases):len(v.aliases)], keys...)
+	return v
+}
+
+func (v Var[T]) Validate(fn func(T) error) Var[T] {
+	v.validators = append(v.validators[:len(v.validators):len(v.validators)], fn)
+	return v
+}
+
+// InRange returns a validator that rejects values outside [min, max].
+func InRange[T cmp.Ordered](min T, max T) func(T) error {
+	return func(value T) error {
+		// Inverted so that NaN, which compares false to everything, fails.
+		if !(value >= min && value <= max) {
+			return fmt.Errorf("value %v is out of range [%v, %v]", value, min, max)
+		}
+
+		return nil
+	}
+}
+
+// Sensitive redacts the value in recorded accesses regardless of the
+// redact function of the recorder.
+func (v Var[T]) Sensitive() Var[T] {
+	v.sensitive = true
+	return v
+}
+
+// From reads the variable from src instead of the package source.
+func (v Var[T]) From(src Source) Var[T] {
+	v.src = src
+	return v
+}
+
+// Lookup returns the parsed value, the default if the variable is not set,
+// or a *NotFoundError if there is no default.
+func (v Var[T]) Lookup() (T, error) {
+	value, access, err := v.read()
+	record(access)
+
+	return value, err
+}
+
+// Get returns the parsed value or, on any error, the default.
+func (v Var[T]) Get() T {
+	value, access, err := v.read()
+	if err != nil {
+		value, access.DefaultUsed = v.def, v.hasDefault
+	}
+	record(access)
+
+	return value
+}
+
+// Must returns the parsed value and panics like must.Must on any error.
+func (v Var[T]) Must() T {
+	return v.mustSkip(1)
+}
+
+// mustSkip panics with a *must.Error that points at the user code; skip is
+// the number of env frames between mustSkip and that code.
+func (v Var[T]) mustSkip(skip int) T {
+	value, err := v.Lookup()
+	if err != nil {
+		check.Fail(skip+1, err)
+	}
+
+	return value
+}
+
+func (v Var[T]) read() (T, Access, error) {
+	key, raw, ok := v.lookup()
+	if !ok {
+		access := Access{Key: v.key, DefaultUsed: v.hasDefault, Sensitive: v.sensitive}
+		if v.hasDefault {
+			return v.def, access, nil
+		}
+
+		return v.def, access, &NotFoundError{Key: v.key}
+	}
+
+	sensitive := v.sensitive || isSecret(raw)
+	value, err := resolve(raw)
+	if err != nil {
+		var zero T
+		err = fmt.Errorf("invalid environment variable '%s' value: %w", key, err)
+		return zero, Access{Key: key, Found: true, Err: err, Sensitive: sensitive}, err
+	}
+
+	parsed, err := v.parseValid(value)
+	if err != nil {
+		err = InvalidValueError(key, raw, v.sensitive, err)
+	}
+
+	return parsed, Access{Key: key, Found: true, Err: err, Value: value, Sensitive: sensitive}, err
+}
+
+func (v Var[T]) parseValid(raw string) (T, error) {
+	value, err := v.parse(raw)
+	for i := 0; err == nil && i < len(v.validators); i++ {
+		err = v.validators[i](value)
+	}
+
+	return value, err
+}
+
+// InvalidValueError wraps a parse or validation error of the variable key,
+// whose unresolved value is raw. Such errors usually quote the value, so
+// they are replaced by a *RedactedError when the variable is sensitive or
+// raw is an encrypted or exec value.
+func InvalidValueError(key string, raw string, sensitive bool, err error) error {
+	if sensitive || isSecret(raw) {
+		return redactError(key, err)
+	}
+
+	return fmt.Errorf("invalid environment variable '%s' value: %w", key, err)
+}
+
+func (v Var[T]) lookup() (string, string, bool) {
+	src := v.src
+	if src == nil {
+		src = currentSource()
+	}
+
+	for _, key := range append([]string{v.key}, v.aliases...) {
+		if raw, ok := src.LookupEnv(key); ok {
+			return key, raw, true
+		}
+	}
+
+	return "", "", false
+}
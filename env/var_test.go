@@ -0,0 +1,177 @@
+package env
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4rchr4y/godevkit/v3/must"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVarLookup(t *testing.T) {
+	t.Run("valid: parsed value", func(t *testing.T) {
+		defer SetSource(MapSource{"PORT": "8080"})()
+
+		value, err := Int("PORT").Lookup()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 8080, value)
+	})
+
+	t.Run("valid: default when not set", func(t *testing.T) {
+		defer SetSource(MapSource{})()
+
+		value, err := Duration("TIMEOUT").Default(time.Second).Lookup()
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second, value)
+	})
+
+	t.Run("valid: alias when primary key is not set", func(t *testing.T) {
+		defer SetSource(MapSource{"PORT_OLD": "9090"})()
+
+		value, err := Int("PORT").Alias("PORT_OLD").Lookup()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 9090, value)
+	})
+
+	t.Run("valid: primary key wins over alias", func(t *testing.T) {
+		defer SetSource(MapSource{"PORT": "8080", "PORT_OLD": "9090"})()
+
+		assert.Equal(t, 8080, Int("PORT").Alias("PORT_OLD").Must())
+	})
+
+	t.Run("valid: explicit source", func(t *testing.T) {
+		assert.Equal(t, "localhost", String("HOST").From(MapSource{"HOST": "localhost"}).Must())
+	})
+
+	t.Run("invalid: not found without default", func(t *testing.T) {
+		defer SetSource(MapSource{})()
+
+		_, err := Int("PORT").Lookup()
+
+		var notFound *NotFoundError
+		assert.True(t, errors.As(err, &notFound))
+		assert.EqualError(t, err, "environment variable 'PORT' was not found")
+	})
+
+	t.Run("invalid: out of range", func(t *testing.T) {
+		defer SetSource(MapSource{"PORT_OLD": "70000"})()
+
+		_, err := Int("PORT").Alias("PORT_OLD").Validate(InRange(1, 65535)).Lookup()
+
+		assert.EqualError(t, err, "invalid environment variable 'PORT_OLD' value: value 70000 is out of range [1, 65535]")
+	})
+
+	t.Run("invalid: custom validator", func(t *testing.T) {
+		defer SetSource(MapSource{"HOST": ""})()
+
+		_, err := String("HOST").Validate(func(s string) error {
+			if s == "" {
+				return errors.New("must not be empty")
+			}
+			return nil
+		}).Lookup()
+
+		assert.EqualError(t, err, "invalid environment variable 'HOST' value: must not be empty")
+	})
+
+	t.Run("invalid: NaN is out of range", func(t *testing.T) {
+		defer SetSource(MapSource{"RATIO": "NaN"})()
+
+		_, err := Float64("RATIO").Validate(InRange(0.0, 1.0)).Lookup()
+
+		assert.EqualError(t, err, "invalid environment variable 'RATIO' value: value NaN is out of range [0, 1]")
+	})
+}
+
+func TestVarGet(t *testing.T) {
+	t.Run("valid: parsed value", func(t *testing.T) {
+		defer SetSource(MapSource{"RATIO": "0.5"})()
+
+		assert.Equal(t, 0.5, Float64("RATIO").Default(1).Get())
+	})
+
+	t.Run("invalid: default on invalid value", func(t *testing.T) {
+		defer SetSource(MapSource{"PORT": "0"})()
+
+		assert.Equal(t, 8080, Int("PORT").Default(8080).Validate(InRange(1, 65535)).Get())
+	})
+
+	t.Run("invalid: zero value without default", func(t *testing.T) {
+		defer SetSource(MapSource{})()
+
+		assert.Equal(t, "", Url("ENDPOINT").Get())
+	})
+}
+
+func TestVarMust(t *testing.T) {
+	t.Run("valid: parsed value", func(t *testing.T) {
+		defer SetSource(MapSource{"MAX": "10"})()
+
+		assert.Equal(t, uint64(10), Uint("MAX").Must())
+	})
+
+	t.Run("invalid: panics on invalid value", func(t *testing.T) {
+		defer SetSource(MapSource{"MAX": "-1"})()
+
+		assert.Panics(t, func() { Uint("MAX").Default(5).Must() })
+	})
+
+	t.Run("invalid: panic value keeps the error chain", func(t *testing.T) {
+		defer SetSource(MapSource{})()
+
+		defer func() {
+			err, ok := recover().(*must.Error)
+			assert.True(t, ok)
+
+			var notFound *NotFoundError
+			assert.True(t, errors.As(err, &notFound))
+			assert.Equal(t, "MAX", notFound.Key)
+		}()
+
+		Uint("MAX").Must()
+	})
+
+	t.Run("invalid: caller is the user code", func(t *testing.T) {
+		defer SetSource(MapSource{})()
+
+		_, _, line, _ := runtime.Caller(0)
+		_, viaVar := must.Try(func() int { return Int("UNSET").Must() })
+		_, viaGetter := must.Try(func() int { return MustGetInt("UNSET") })
+
+		for _, err := range []error{viaVar, viaGetter} {
+			caller := err.(*must.Error).Caller()
+			assert.True(t, strings.HasSuffix(caller.File, "var_test.go"), caller.File)
+		}
+		assert.Equal(t, line+1, viaVar.(*must.Error).Caller().Line)
+		assert.Equal(t, line+2, viaGetter.(*must.Error).Caller().Line)
+	})
+}
+
+func TestVarSensitive(t *testing.T) {
+	t.Run("valid: sensitive values are always redacted", func(t *testing.T) {
+		defer SetSource(MapSource{"PASSWORD": "hunter2"})()
+
+		r := NewMemoryRecorder()
+		defer SetRecorder(r, RedactNone)()
+
+		String("PASSWORD").Sensitive().Get()
+
+		assert.Equal(t, []Access{{Key: "PASSWORD", Found: true, Sensitive: true, Value: "[REDACTED]"}}, r.Accesses())
+	})
+}
+
+func TestVarIsImmutable(t *testing.T) {
+	defer SetSource(MapSource{"PORT": "8080"})()
+
+	base := Int("PORT").Alias("PORT_A")
+	limited := base.Validate(InRange(1, 1000))
+
+	assert.Equal(t, 8080, base.Must())
+	assert.Panics(t, func() { limited.Must() })
+}
@@ -0,0 +1,186 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/4rchr4y/godevkit/v3/syswrap/osiface"
+)
+
+// LoadFunc builds and validates a configuration from its sources.
+type LoadFunc[T any] func() (T, error)
+
+// Watcher keeps the latest valid configuration produced by a LoadFunc and
+// reloads it when one of the watched paths changes or SIGHUP arrives. A
+// failed reload keeps the previous configuration.
+type Watcher[T any] struct {
+	osw   osiface.OSWrapper
+	load  LoadFunc[T]
+	paths []string
+
+	current atomic.Pointer[T]
+
+	// reloadMu keeps load, publication and notification of one reload
+	// from interleaving with another, so subscribers see configs in order.
+	reloadMu sync.Mutex
+
+	mu          sync.Mutex
+	modTimes    map[string]time.Time
+	subscribers map[int]func(T)
+	nextID      int
+	onError     func(error)
+
+	notify func(c chan<- os.Signal, sig ...os.Signal)
+}
+
+// NewWatcher performs the initial load and fails if it does not succeed.
+func NewWatcher[T any](osw osiface.OSWrapper, load LoadFunc[T], paths ...string) (*Watcher[T], error) {
+	w := &Watcher[T]{
+		osw:         osw,
+		load:        load,
+		paths:       paths,
+		subscribers: make(map[int]func(T)),
+		notify:      signal.Notify,
+	}
+
+	w.modTimes = w.stat()
+	if err := w.Reload(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *Watcher[T]) Current() T {
+	return *w.current.Load()
+}
+
+// Subscribe registers fn to receive every configuration published after
+// a successful reload. fn runs while the reload is in progress and must not
+// call Reload.
+func (w *Watcher[T]) Subscribe(fn func(T)) (unsubscribe func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	id := w.nextID
+	w.nextID++
+	w.subscribers[id] = fn
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		delete(w.subscribers, id)
+	}
+}
+
+// OnError registers fn to receive the errors of reloads triggered by Run.
+func (w *Watcher[T]) OnError(fn func(error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.onError = fn
+}
+
+func (w *Watcher[T]) Reload() error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	config, err := w.load()
+	if err != nil {
+		return err
+	}
+
+	w.current.Store(&config)
+
+	w.mu.Lock()
+	subscribers := make([]func(T), 0, len(w.subscribers))
+	for _, fn := range w.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	w.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(config)
+	}
+
+	return nil
+}
+
+// Run polls the mod times of the watched paths every interval and listens
+// for SIGHUP until ctx is done.
+func (w *Watcher[T]) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("watch interval must be positive, got %s", interval)
+	}
+
+	hup := make(chan os.Signal, 1)
+	w.notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-hup:
+			w.reload()
+		case <-ticker.C:
+			if w.changed() {
+				w.reload()
+			}
+		}
+	}
+}
+
+func (w *Watcher[T]) reload() {
+	if err := w.Reload(); err != nil {
+		w.mu.Lock()
+		onError := w.onError
+		w.mu.Unlock()
+
+		if onError != nil {
+			onError(err)
+		}
+	}
+}
+
+func (w *Watcher[T]) changed() bool {
+	modTimes := w.stat()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	changed := false
+	for path, modTime := range modTimes {
+		if !w.modTimes[path].Equal(modTime) {
+			changed = true
+		}
+	}
+	w.modTimes = modTimes
+
+	return changed
+}
+
+// stat records a zero time for missing paths so that creating or removing
+// a file counts as a change.
+func (w *Watcher[T]) stat() map[string]time.Time {
+	modTimes := make(map[string]time.Time, len(w.paths))
+	for _, path := range w.paths {
+		var modTime time.Time
+		if info, err := w.osw.Stat(path); err == nil {
+			modTime = info.ModTime()
+		}
+		modTimes[path] = modTime
+	}
+
+	return modTimes
+}
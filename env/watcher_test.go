@@ -0,0 +1,149 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/4rchr4y/godevkit/v3/syswrap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testWatchedConfig struct {
+	LogLevel string
+}
+
+func loadTestWatchedConfig(path string) LoadFunc[testWatchedConfig] {
+	return func() (testWatchedConfig, error) {
+		p, err := LoadProfile(syswrap.OSWrap{}, filepath.Dir(path), "")
+		if err != nil {
+			return testWatchedConfig{}, err
+		}
+
+		level, ok := p.Values.LookupEnv("LOG_LEVEL")
+		if !ok {
+			return testWatchedConfig{}, errors.New("LOG_LEVEL is required")
+		}
+
+		return testWatchedConfig{LogLevel: level}, nil
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	t.Run("valid: reload on file change", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".env")
+		require.NoError(t, os.WriteFile(path, []byte("LOG_LEVEL=info\n"), 0o600))
+
+		w, err := NewWatcher(syswrap.OSWrap{}, loadTestWatchedConfig(path), path)
+		require.NoError(t, err)
+		assert.Equal(t, "info", w.Current().LogLevel)
+
+		published := make(chan testWatchedConfig, 1)
+		w.Subscribe(func(c testWatchedConfig) { published <- c })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go w.Run(ctx, 10*time.Millisecond)
+
+		require.NoError(t, os.WriteFile(path, []byte("LOG_LEVEL=debug\n"), 0o600))
+		require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Hour)))
+
+		select {
+		case c := <-published:
+			assert.Equal(t, "debug", c.LogLevel)
+			assert.Equal(t, "debug", w.Current().LogLevel)
+		case <-time.After(5 * time.Second):
+			t.Fatal("configuration was not reloaded")
+		}
+	})
+
+	t.Run("valid: reload on SIGHUP", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".env")
+		require.NoError(t, os.WriteFile(path, []byte("LOG_LEVEL=info\n"), 0o600))
+
+		w, err := NewWatcher(syswrap.OSWrap{}, loadTestWatchedConfig(path))
+		require.NoError(t, err)
+
+		hup := make(chan chan<- os.Signal, 1)
+		w.notify = func(c chan<- os.Signal, _ ...os.Signal) { hup <- c }
+
+		published := make(chan testWatchedConfig, 1)
+		w.Subscribe(func(c testWatchedConfig) { published <- c })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go w.Run(ctx, time.Hour)
+
+		require.NoError(t, os.WriteFile(path, []byte("LOG_LEVEL=warn\n"), 0o600))
+		(<-hup) <- syscall.SIGHUP
+
+		select {
+		case c := <-published:
+			assert.Equal(t, "warn", c.LogLevel)
+		case <-time.After(5 * time.Second):
+			t.Fatal("configuration was not reloaded")
+		}
+	})
+
+	t.Run("invalid: failed validation keeps the old config", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".env")
+		require.NoError(t, os.WriteFile(path, []byte("LOG_LEVEL=info\n"), 0o600))
+
+		w, err := NewWatcher(syswrap.OSWrap{}, loadTestWatchedConfig(path), path)
+		require.NoError(t, err)
+
+		notified := false
+		w.Subscribe(func(testWatchedConfig) { notified = true })
+
+		require.NoError(t, os.WriteFile(path, []byte("OTHER=value\n"), 0o600))
+
+		assert.Error(t, w.Reload())
+		assert.Equal(t, "info", w.Current().LogLevel)
+		assert.False(t, notified)
+	})
+
+	t.Run("valid: concurrent reloads publish in order", func(t *testing.T) {
+		var version atomic.Int32
+		w, err := NewWatcher(syswrap.OSWrap{}, func() (int32, error) {
+			return version.Add(1), nil
+		})
+		require.NoError(t, err)
+
+		var published []int32
+		w.Subscribe(func(v int32) { published = append(published, v) })
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, w.Reload())
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, published, 8)
+		assert.IsIncreasing(t, published)
+		assert.Equal(t, published[len(published)-1], w.Current())
+	})
+
+	t.Run("invalid: non-positive interval", func(t *testing.T) {
+		w, err := NewWatcher(syswrap.OSWrap{}, func() (int, error) { return 1, nil })
+		require.NoError(t, err)
+
+		assert.EqualError(t, w.Run(context.Background(), 0), "watch interval must be positive, got 0s")
+	})
+
+	t.Run("invalid: initial load fails", func(t *testing.T) {
+		_, err := NewWatcher(syswrap.OSWrap{}, loadTestWatchedConfig(filepath.Join(t.TempDir(), ".env")))
+
+		assert.Error(t, err)
+	})
+}
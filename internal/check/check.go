@@ -0,0 +1,134 @@
+// Package check holds the assertions shared by must and must/debug, and
+// the Fail hook other packages of the module use to raise must failures.
+// Every function takes skip, the number of frames between its caller and
+// the user code, so that the raised error points at the user code whichever
+// package it is called through.
+package check
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Fail raises a failed assertion; skip counts the frames above Fail's
+// caller that belong to the assertion. It is installed by package must.
+var Fail func(skip int, err error)
+
+func True(skip int, cond bool, msg string) {
+	if !cond {
+		Fail(skip+1, assertionError(msg, "condition must be true", ""))
+	}
+}
+
+func NotNil[T any](skip int, value T, msg string) T {
+	if isNil(value) {
+		Fail(skip+1, assertionError(msg, "value must not be nil", ""))
+	}
+
+	return value
+}
+
+func Equal[T comparable](skip int, got T, want T, msg string) T {
+	if got != want {
+		Fail(skip+1, assertionError(msg, "values must be equal", fmt.Sprintf("got %#v, want %#v", got, want)))
+	}
+
+	return got
+}
+
+func NotEmpty[S ~[]E, E any](skip int, s S, msg string) S {
+	if len(s) == 0 {
+		Fail(skip+1, assertionError(msg, "slice must not be empty", ""))
+	}
+
+	return s
+}
+
+func NotEmptyString[S ~string](skip int, s S, msg string) S {
+	if len(s) == 0 {
+		Fail(skip+1, assertionError(msg, "string must not be empty", ""))
+	}
+
+	return s
+}
+
+func InRange[T cmp.Ordered](skip int, value T, min T, max T, msg string) T {
+	// Inverted so that NaN, which compares false to everything, fails.
+	if !(value >= min && value <= max) {
+		Fail(skip+1, assertionError(msg, "value must be in range", fmt.Sprintf("got %v, want [%v, %v]", value, min, max)))
+	}
+
+	return value
+}
+
+func Len[S ~[]E, E any](skip int, s S, n int, msg string) S {
+	if len(s) != n {
+		Fail(skip+1, assertionError(msg, "slice must have the expected length", fmt.Sprintf("got %d, want %d", len(s), n)))
+	}
+
+	return s
+}
+
+// smallSliceLen is the length up to which NoDuplicates compares pairs
+// instead of allocating a map.
+const smallSliceLen = 16
+
+func NoDuplicates[S ~[]E, E comparable](skip int, s S, msg string) S {
+	if i, j, ok := findDuplicate(s); ok {
+		Fail(skip+1, assertionError(msg, "slice must not contain duplicates", fmt.Sprintf("%#v at indexes %d and %d", s[j], i, j)))
+	}
+
+	return s
+}
+
+func findDuplicate[S ~[]E, E comparable](s S) (int, int, bool) {
+	if len(s) <= smallSliceLen {
+		for j := range s {
+			for i := 0; i < j; i++ {
+				if s[i] == s[j] {
+					return i, j, true
+				}
+			}
+		}
+
+		return 0, 0, false
+	}
+
+	seen := make(map[E]int, len(s))
+	for j, v := range s {
+		if i, ok := seen[v]; ok {
+			return i, j, true
+		}
+
+		seen[v] = j
+	}
+
+	return 0, 0, false
+}
+
+func assertionError(msg string, defaultMsg string, details string) error {
+	if msg == "" {
+		msg = defaultMsg
+	}
+
+	if details == "" {
+		return errors.New(msg)
+	}
+
+	return fmt.Errorf("%s: %s", msg, details)
+}
+
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,49 @@
+package must
+
+import (
+	"cmp"
+
+	"github.com/4rchr4y/godevkit/v3/internal/check"
+)
+
+// The assertions below only build their message when they fail, so the
+// happy path does not allocate. msg may be empty, in which case a default
+// description is used; the offending values are always appended.
+
+func init() {
+	check.Fail = func(skip int, err error) {
+		fail(newError(skip+1, err))
+	}
+}
+
+func True(cond bool, msg string) {
+	check.True(1, cond, msg)
+}
+
+func NotNil[T any](value T, msg string) T {
+	return check.NotNil(1, value, msg)
+}
+
+func Equal[T comparable](got T, want T, msg string) T {
+	return check.Equal(1, got, want, msg)
+}
+
+func NotEmpty[S ~[]E, E any](s S, msg string) S {
+	return check.NotEmpty(1, s, msg)
+}
+
+func NotEmptyString[S ~string](s S, msg string) S {
+	return check.NotEmptyString(1, s, msg)
+}
+
+func InRange[T cmp.Ordered](value T, min T, max T, msg string) T {
+	return check.InRange(1, value, min, max, msg)
+}
+
+func Len[S ~[]E, E any](s S, n int, msg string) S {
+	return check.Len(1, s, n, msg)
+}
+
+func NoDuplicates[S ~[]E, E comparable](s S, msg string) S {
+	return check.NoDuplicates(1, s, msg)
+}
@@ -0,0 +1,138 @@
+package must
+
+import (
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrue(t *testing.T) {
+	t.Run("valid: true condition", func(t *testing.T) {
+		assert.NotPanics(t, func() { True(true, "") })
+	})
+
+	t.Run("invalid: default message", func(t *testing.T) {
+		assert.PanicsWithError(t, "condition must be true", func() { True(false, "") })
+	})
+
+	t.Run("invalid: custom message", func(t *testing.T) {
+		assert.PanicsWithError(t, "workers must be positive", func() { True(false, "workers must be positive") })
+	})
+}
+
+func TestNotNil(t *testing.T) {
+	t.Run("valid: non-nil values", func(t *testing.T) {
+		value := 10
+		assert.Equal(t, &value, NotNil(&value, ""))
+		assert.NotPanics(t, func() { NotNil[io.Reader](io.MultiReader(), "") })
+		assert.NotPanics(t, func() { NotNil(0, "") })
+	})
+
+	t.Run("invalid: nil pointer", func(t *testing.T) {
+		assert.PanicsWithError(t, "value must not be nil", func() { NotNil[*int](nil, "") })
+	})
+
+	t.Run("invalid: nil interface", func(t *testing.T) {
+		assert.PanicsWithError(t, "reader is required", func() { NotNil[io.Reader](nil, "reader is required") })
+	})
+
+	t.Run("invalid: nil map", func(t *testing.T) {
+		assert.Panics(t, func() { NotNil[map[string]int](nil, "") })
+	})
+}
+
+func TestEqual(t *testing.T) {
+	t.Run("valid: equal values", func(t *testing.T) {
+		assert.Equal(t, "a", Equal("a", "a", ""))
+	})
+
+	t.Run("invalid: prints both values", func(t *testing.T) {
+		assert.PanicsWithError(t, `unexpected mode: got "dev", want "prod"`, func() { Equal("dev", "prod", "unexpected mode") })
+	})
+}
+
+func TestNotEmpty(t *testing.T) {
+	t.Run("valid: non-empty slice", func(t *testing.T) {
+		assert.Equal(t, []int{1}, NotEmpty([]int{1}, ""))
+	})
+
+	t.Run("invalid: empty slice", func(t *testing.T) {
+		assert.PanicsWithError(t, "slice must not be empty", func() { NotEmpty([]int{}, "") })
+	})
+
+	t.Run("valid: non-empty string", func(t *testing.T) {
+		assert.Equal(t, "a", NotEmptyString("a", ""))
+	})
+
+	t.Run("invalid: empty string", func(t *testing.T) {
+		assert.PanicsWithError(t, "name is required", func() { NotEmptyString("", "name is required") })
+	})
+}
+
+func TestInRange(t *testing.T) {
+	t.Run("valid: bounds are inclusive", func(t *testing.T) {
+		assert.Equal(t, 1, InRange(1, 1, 10, ""))
+		assert.Equal(t, 10, InRange(10, 1, 10, ""))
+	})
+
+	t.Run("invalid: out of range", func(t *testing.T) {
+		assert.PanicsWithError(t, "port: got 70000, want [1, 65535]", func() { InRange(70000, 1, 65535, "port") })
+	})
+
+	t.Run("invalid: NaN", func(t *testing.T) {
+		assert.PanicsWithError(t, "value must be in range: got NaN, want [0, 1]", func() { InRange(math.NaN(), 0.0, 1.0, "") })
+	})
+}
+
+func TestLen(t *testing.T) {
+	t.Run("valid: expected length", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b"}, Len([]string{"a", "b"}, 2, ""))
+	})
+
+	t.Run("invalid: wrong length", func(t *testing.T) {
+		assert.PanicsWithError(t, "slice must have the expected length: got 1, want 2", func() { Len([]string{"a"}, 2, "") })
+	})
+}
+
+func TestNoDuplicates(t *testing.T) {
+	t.Run("valid: unique values", func(t *testing.T) {
+		assert.Equal(t, []int{1, 2, 3}, NoDuplicates([]int{1, 2, 3}, ""))
+	})
+
+	t.Run("invalid: duplicate in small slice", func(t *testing.T) {
+		assert.PanicsWithError(t, `slice must not contain duplicates: "b" at indexes 1 and 3`, func() {
+			NoDuplicates([]string{"a", "b", "c", "b"}, "")
+		})
+	})
+
+	t.Run("invalid: duplicate in large slice", func(t *testing.T) {
+		s := make([]int, 100)
+		for i := range s {
+			s[i] = i
+		}
+		s[99] = 42
+
+		assert.PanicsWithError(t, "slice must not contain duplicates: 42 at indexes 42 and 99", func() { NoDuplicates(s, "") })
+	})
+}
+
+func TestAssertionsDoNotAllocate(t *testing.T) {
+	value := 10
+	ptr := &value
+	s := []int{1, 2, 3}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		True(value > 0, "value must be positive")
+		NotNil(ptr, "")
+		Equal(value, 10, "")
+		NotEmpty(s, "")
+		NotEmptyString("name", "")
+		InRange(value, 1, 100, "")
+		Len(s, 3, "")
+		NoDuplicates(s, "")
+	})
+
+	assert.Zero(t, allocs)
+}
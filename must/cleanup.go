@@ -0,0 +1,67 @@
+package must
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// Close closes c and joins its error into the named return error err, so
+// write errors reported on close are not lost:
+//
+//	func write(osw osiface.OSWrapper, name string, data []byte) (err error) {
+//		f, err := osw.CreateFile(name)
+//		if err != nil {
+//			return err
+//		}
+//		defer must.Close(&err, f)
+//
+//		_, err = f.Write(data)
+//		return err
+//	}
+func Close(err *error, c io.Closer) {
+	if closeErr := c.Close(); closeErr != nil {
+		*err = errors.Join(*err, closeErr)
+	}
+}
+
+// Cleanup is a stack of cleanup functions run in reverse registration
+// order. The zero value is ready to use and safe for concurrent use.
+type Cleanup struct {
+	mu  sync.Mutex
+	fns []func() error
+}
+
+func (c *Cleanup) Add(fn func() error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fns = append(c.fns, fn)
+}
+
+func (c *Cleanup) AddCloser(closer io.Closer) {
+	c.Add(closer.Close)
+}
+
+// Run calls every registered function, last registered first, and returns
+// their errors joined. The stack is empty afterwards.
+func (c *Cleanup) Run() error {
+	c.mu.Lock()
+	fns := c.fns
+	c.fns = nil
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(fns) - 1; i >= 0; i-- {
+		if err := fns[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close runs the cleanup stack, making Cleanup an io.Closer.
+func (c *Cleanup) Close() error {
+	return c.Run()
+}
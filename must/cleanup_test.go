@@ -0,0 +1,129 @@
+package must
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/4rchr4y/godevkit/v3/syswrap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type closerFunc func() error
+
+func (fn closerFunc) Close() error {
+	return fn()
+}
+
+func TestClose(t *testing.T) {
+	errClose := errors.New("close failed")
+
+	t.Run("valid: successful close keeps nil error", func(t *testing.T) {
+		var err error
+		Close(&err, closerFunc(func() error { return nil }))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid: close error is returned", func(t *testing.T) {
+		fn := func() (err error) {
+			defer Close(&err, closerFunc(func() error { return errClose }))
+			return nil
+		}
+
+		assert.ErrorIs(t, fn(), errClose)
+	})
+
+	t.Run("invalid: close error is joined with the returned error", func(t *testing.T) {
+		errWrite := errors.New("write failed")
+		fn := func() (err error) {
+			defer Close(&err, closerFunc(func() error { return errClose }))
+			return errWrite
+		}
+
+		err := fn()
+
+		assert.ErrorIs(t, err, errWrite)
+		assert.ErrorIs(t, err, errClose)
+	})
+
+	t.Run("invalid: closing a file twice", func(t *testing.T) {
+		f, err := syswrap.OSWrap{}.CreateFile(filepath.Join(t.TempDir(), "file"))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		fn := func() (err error) {
+			defer Close(&err, f)
+			return nil
+		}
+
+		assert.ErrorIs(t, fn(), os.ErrClosed)
+	})
+}
+
+func TestCleanup(t *testing.T) {
+	t.Run("valid: runs in LIFO order", func(t *testing.T) {
+		var order []int
+		var c Cleanup
+		for i := 0; i < 3; i++ {
+			i := i
+			c.Add(func() error {
+				order = append(order, i)
+				return nil
+			})
+		}
+
+		assert.NoError(t, c.Run())
+		assert.Equal(t, []int{2, 1, 0}, order)
+	})
+
+	t.Run("valid: stack is empty after run", func(t *testing.T) {
+		calls := 0
+		var c Cleanup
+		c.Add(func() error {
+			calls++
+			return nil
+		})
+
+		c.Run()
+		c.Run()
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("invalid: errors are aggregated and every cleanup runs", func(t *testing.T) {
+		first, second := errors.New("first"), errors.New("second")
+		ran := false
+
+		var c Cleanup
+		c.Add(func() error { return first })
+		c.Add(func() error {
+			ran = true
+			return nil
+		})
+		c.Add(func() error { return second })
+
+		err := c.Close()
+
+		assert.True(t, ran)
+		assert.ErrorIs(t, err, first)
+		assert.ErrorIs(t, err, second)
+		assert.EqualError(t, err, "second\nfirst")
+	})
+
+	t.Run("valid: closes syswrap files", func(t *testing.T) {
+		osw := syswrap.OSWrap{}
+		dir := t.TempDir()
+
+		var c Cleanup
+		for _, name := range []string{"a", "b"} {
+			f, err := osw.CreateFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+			c.AddCloser(f)
+		}
+
+		assert.NoError(t, c.Run())
+	})
+}
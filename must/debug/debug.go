@@ -0,0 +1,15 @@
+// Package debug provides the assertions of the must package for checks that
+// should only run in development builds. They are active when building with
+// the godevkit_debug tag:
+//
+//	go test -tags godevkit_debug ./...
+//
+// and compile to no-ops otherwise. Arguments are still evaluated in release
+// builds, so guard expensive checks with Enabled or pass them to That:
+//
+//	if debug.Enabled {
+//		debug.NoDuplicates(ids, "ids must be unique")
+//	}
+//
+//	debug.That(func() bool { return isSorted(items) }, "items must be sorted")
+package debug
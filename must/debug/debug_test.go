@@ -0,0 +1,94 @@
+package debug_test
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/4rchr4y/godevkit/v3/must"
+	"github.com/4rchr4y/godevkit/v3/must/debug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertions(t *testing.T) {
+	t.Run("valid: passing assertions never panic", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			debug.That(func() bool { return true }, "")
+			debug.True(true, "")
+			assert.Equal(t, 1, debug.Equal(1, 1, ""))
+			assert.Equal(t, []int{1, 2}, debug.NoDuplicates([]int{1, 2}, ""))
+		})
+	})
+
+	t.Run("valid: failing assertions panic only in debug builds", func(t *testing.T) {
+		failing := map[string]func(){
+			"That":           func() { debug.That(func() bool { return false }, "") },
+			"True":           func() { debug.True(false, "") },
+			"NotNil":         func() { debug.NotNil[*int](nil, "") },
+			"Equal":          func() { debug.Equal(1, 2, "") },
+			"NotEmpty":       func() { debug.NotEmpty([]int{}, "") },
+			"NotEmptyString": func() { debug.NotEmptyString("", "") },
+			"InRange":        func() { debug.InRange(0, 1, 2, "") },
+			"Len":            func() { debug.Len([]int{}, 1, "") },
+			"NoDuplicates":   func() { debug.NoDuplicates([]int{1, 1}, "") },
+		}
+
+		for name, fn := range failing {
+			if debug.Enabled {
+				assert.Panics(t, fn, name)
+			} else {
+				assert.NotPanics(t, fn, name)
+			}
+		}
+	})
+
+	t.Run("valid: caller is outside the debug package", func(t *testing.T) {
+		if !debug.Enabled {
+			t.Skip("requires the godevkit_debug build tag")
+		}
+
+		_, _, line, _ := runtime.Caller(0)
+		err, ok := func() (err error, ok bool) {
+			defer func() { err, ok = recover().(*must.Error) }()
+			debug.True(false, "")
+			return nil, false
+		}()
+
+		assert.True(t, ok)
+		assert.Equal(t, line+3, err.(*must.Error).Caller().Line)
+		assert.True(t, strings.HasSuffix(err.(*must.Error).Caller().File, "debug_test.go"))
+	})
+}
+
+var (
+	benchIDs   = []int{1, 2, 3, 4, 5, 6, 7, 8}
+	benchValue = 42
+)
+
+func BenchmarkNoDuplicates(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		debug.NoDuplicates(benchIDs, "")
+	}
+}
+
+func BenchmarkThat(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		debug.That(func() bool { return benchValue > 0 }, "")
+	}
+}
+
+func BenchmarkInRange(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		debug.InRange(benchValue, 0, 100, "")
+	}
+}
+
+// BenchmarkBaseline is the empty loop release builds should match.
+func BenchmarkBaseline(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+	}
+}
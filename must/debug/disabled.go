@@ -0,0 +1,39 @@
+//go:build !godevkit_debug
+
+package debug
+
+import "cmp"
+
+const Enabled = false
+
+func That(fn func() bool, msg string) {}
+
+func True(cond bool, msg string) {}
+
+func NotNil[T any](value T, msg string) T {
+	return value
+}
+
+func Equal[T comparable](got T, want T, msg string) T {
+	return got
+}
+
+func NotEmpty[S ~[]E, E any](s S, msg string) S {
+	return s
+}
+
+func NotEmptyString[S ~string](s S, msg string) S {
+	return s
+}
+
+func InRange[T cmp.Ordered](value T, min T, max T, msg string) T {
+	return value
+}
+
+func Len[S ~[]E, E any](s S, n int, msg string) S {
+	return s
+}
+
+func NoDuplicates[S ~[]E, E comparable](s S, msg string) S {
+	return s
+}
@@ -0,0 +1,107 @@
+package must
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+)
+
+const maxStackDepth = 32
+
+// Error is the value every must function panics with. It wraps the
+// original errors, so recovered panics can be inspected with errors.Is and
+// errors.As, and records where the must function was called from.
+//
+// Printed with %v or %s it shows the error messages joined by "; ", with
+// %+v it also shows the caller and the stack.
+type Error struct {
+	errs  []error
+	err   error
+	stack []runtime.Frame
+}
+
+// newError captures the stack above the caller of the must function; skip
+// is the number of must frames between newError and that caller.
+func newError(skip int, errs ...error) *Error {
+	return newErrorAt(callers(skip+1), errs...)
+}
+
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	return pcs[:runtime.Callers(skip+2, pcs)]
+}
+
+// newErrorAt builds an Error with the stack captured earlier by callers.
+func newErrorAt(pcs []uintptr, errs ...error) *Error {
+	frames := runtime.CallersFrames(pcs)
+
+	stack := make([]runtime.Frame, 0, len(pcs))
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, frame)
+		if !more {
+			break
+		}
+	}
+
+	err := errs[0]
+	if len(errs) > 1 {
+		err = errors.Join(errs...)
+	}
+
+	return &Error{errs: errs, err: err, stack: stack}
+}
+
+func (e *Error) Error() string {
+	if len(e.errs) == 1 {
+		return e.err.Error()
+	}
+
+	messages := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Caller returns the frame that called the must function.
+func (e *Error) Caller() runtime.Frame {
+	if len(e.stack) == 0 {
+		return runtime.Frame{}
+	}
+
+	return e.stack[0]
+}
+
+// Stack returns the frames from the caller of the must function upwards.
+func (e *Error) Stack() []runtime.Frame {
+	stack := make([]runtime.Frame, len(e.stack))
+	copy(stack, e.stack)
+
+	return stack
+}
+
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, e.Error())
+			for _, frame := range e.Stack() {
+				fmt.Fprintf(s, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
@@ -0,0 +1,90 @@
+package must
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recoverError(fn func()) (err *Error) {
+	defer func() {
+		err = recover().(*Error)
+	}()
+
+	fn()
+	return nil
+}
+
+func TestError(t *testing.T) {
+	t.Run("valid: Must wraps the original error", func(t *testing.T) {
+		cause := fmt.Errorf("loading config: %w", fs.ErrNotExist)
+
+		err := recoverError(func() { Must(0, cause) })
+
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		assert.EqualError(t, err, "loading config: file does not exist")
+	})
+
+	t.Run("valid: multiple errors are joined", func(t *testing.T) {
+		first, second := errors.New("first"), errors.New("second")
+
+		err := recoverError(func() { MustBeOk(0, false, first, second) })
+
+		assert.ErrorIs(t, err, first)
+		assert.ErrorIs(t, err, second)
+		assert.EqualError(t, err, "first; second")
+	})
+
+	t.Run("valid: errors.As finds typed causes", func(t *testing.T) {
+		cause := &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist}
+
+		err := recoverError(func() { Must(0, cause) })
+
+		var pathErr *fs.PathError
+		assert.True(t, errors.As(err, &pathErr))
+		assert.Equal(t, "config.yaml", pathErr.Path)
+	})
+
+	t.Run("valid: default message without errors", func(t *testing.T) {
+		err := recoverError(func() { MustNotBeOk(0, true) })
+
+		assert.EqualError(t, err, "value must not be ok")
+	})
+
+	t.Run("valid: caller is the call site of the must function", func(t *testing.T) {
+		_, _, line, _ := runtime.Caller(0)
+		mustErr := recoverError(func() { Must(0, errors.New("boom")) })
+		beOkErr := recoverError(func() { MustBeOk(0, false) })
+		doErr := recoverError(func() { Dof(errors.New("boom"), "context") })
+
+		assert.Equal(t, line+1, mustErr.Caller().Line)
+		assert.Equal(t, line+2, beOkErr.Caller().Line)
+		assert.Equal(t, line+3, doErr.Caller().Line)
+		assert.True(t, strings.HasSuffix(mustErr.Caller().File, "error_test.go"))
+	})
+
+	t.Run("valid: stack starts at the caller", func(t *testing.T) {
+		err := recoverError(func() { Must(0, errors.New("boom")) })
+
+		stack := err.Stack()
+		assert.NotEmpty(t, stack)
+		assert.Equal(t, err.Caller(), stack[0])
+	})
+
+	t.Run("valid: formatting", func(t *testing.T) {
+		err := recoverError(func() { Must(0, errors.New("boom")) })
+
+		assert.Equal(t, "boom", fmt.Sprintf("%v", err))
+		assert.Equal(t, "boom", fmt.Sprintf("%s", err))
+		assert.Equal(t, `"boom"`, fmt.Sprintf("%q", err))
+
+		detailed := fmt.Sprintf("%+v", err)
+		assert.True(t, strings.HasPrefix(detailed, "boom\n"))
+		assert.Contains(t, detailed, "error_test.go:")
+	})
+}
@@ -0,0 +1,172 @@
+package must
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// Group runs functions concurrently, like errgroup, but also turns must
+// panics inside them into errors instead of crashing the process. The
+// first failure cancels the context returned by WithContext.
+type Group struct {
+	cancel context.CancelCauseFunc
+
+	wg sync.WaitGroup
+
+	mu      sync.Mutex
+	errs    []*Error
+	cause   error // set once a failure cancelled the context
+	foreign *PanicError
+}
+
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// Go runs fn in a new goroutine. A returned error is reported with the
+// stack of the Go call; a must panic, or a returned error wrapping one,
+// keeps the stack it was raised with. Other panics are re-raised by Wait.
+func (g *Group) Go(fn func() error) {
+	pcs := callers(1)
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer g.recover()
+
+		if err := fn(); err != nil {
+			var mustErr *Error
+			if !errors.As(err, &mustErr) {
+				mustErr = newErrorAt(pcs, err)
+			}
+			g.fail(mustErr)
+		}
+	}()
+}
+
+func (g *Group) recover() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	if err, ok := r.(*Error); ok {
+		g.fail(err)
+		return
+	}
+
+	cause := fmt.Errorf("panic: %v", r)
+
+	g.mu.Lock()
+	if g.foreign == nil {
+		g.foreign = &PanicError{Value: r, Stack: debug.Stack()}
+	}
+	if g.cause == nil {
+		g.cause = cause
+	}
+	g.mu.Unlock()
+
+	if g.cancel != nil {
+		g.cancel(cause)
+	}
+}
+
+func (g *Group) fail(err *Error) {
+	g.mu.Lock()
+	if g.cause != nil && (errors.Is(err, context.Canceled) || errors.Is(err, g.cause)) {
+		g.mu.Unlock()
+		return
+	}
+
+	g.errs = append(g.errs, err)
+	if g.cause == nil {
+		g.cause = err
+	}
+	g.mu.Unlock()
+
+	if g.cancel != nil {
+		g.cancel(err)
+	}
+}
+
+// Wait blocks until every function returned and reports their failures as
+// a *GroupError, or nil if all succeeded. Errors that only report the
+// cancellation caused by an earlier failure are dropped, so the first
+// failure is always Errors[0]. A foreign panic is re-raised as a
+// *PanicError carrying the stack of the goroutine that panicked.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(nil)
+	}
+
+	if g.foreign != nil {
+		panic(g.foreign)
+	}
+
+	if len(g.errs) == 0 {
+		return nil
+	}
+
+	return &GroupError{Errors: g.errs}
+}
+
+// PanicError is a panic not raised by this package, recovered in another
+// goroutine.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n\n%s", e.Value, e.Stack)
+}
+
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// GroupError holds the failures of a Group in the order they happened.
+// Printed with %+v it includes the stack of each failure.
+type GroupError struct {
+	Errors []*Error
+}
+
+func (e *GroupError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (e *GroupError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+func (e *GroupError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		for i, err := range e.Errors {
+			if i > 0 {
+				io.WriteString(s, "\n\n")
+			}
+			fmt.Fprintf(s, "%+v", err)
+		}
+		return
+	}
+
+	io.WriteString(s, e.Error())
+}
@@ -0,0 +1,154 @@
+package must
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("valid: all functions succeed", func(t *testing.T) {
+		g, _ := WithContext(context.Background())
+		results := make([]int, 3)
+		for i := range results {
+			i := i
+			g.Go(func() error {
+				results[i] = i * 2
+				return nil
+			})
+		}
+
+		assert.NoError(t, g.Wait())
+		assert.Equal(t, []int{0, 2, 4}, results)
+	})
+
+	t.Run("valid: zero value group", func(t *testing.T) {
+		var g Group
+		g.Go(func() error { return errors.New("boom") })
+
+		assert.EqualError(t, g.Wait(), "boom")
+	})
+
+	t.Run("invalid: must panic becomes an error", func(t *testing.T) {
+		g, _ := WithContext(context.Background())
+		g.Go(func() error {
+			Must(0, fs.ErrNotExist)
+			return nil
+		})
+
+		err := g.Wait()
+
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+
+		var groupErr *GroupError
+		assert.True(t, errors.As(err, &groupErr))
+		assert.Contains(t, groupErr.Errors[0].Caller().File, "group_test.go")
+	})
+
+	t.Run("invalid: returned must error keeps its stack", func(t *testing.T) {
+		var line int
+		g, _ := WithContext(context.Background())
+		g.Go(func() error {
+			_, err := Try(func() int {
+				_, _, line, _ = runtime.Caller(0)
+				return Must(0, fs.ErrNotExist)
+			})
+			return err
+		})
+
+		var groupErr *GroupError
+		assert.True(t, errors.As(g.Wait(), &groupErr))
+		assert.Equal(t, line+1, groupErr.Errors[0].Caller().Line)
+	})
+
+	t.Run("invalid: first failure cancels siblings", func(t *testing.T) {
+		g, ctx := WithContext(context.Background())
+		g.Go(func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+				return nil
+			}
+		})
+		g.Go(func() error { return errors.New("boom") })
+
+		err := g.Wait()
+
+		assert.EqualError(t, err, "boom")
+		assert.ErrorIs(t, context.Cause(ctx), err.(*GroupError).Errors[0])
+		assert.NotErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("invalid: cancellation without a failure is reported", func(t *testing.T) {
+		parent, cancel := context.WithCancel(context.Background())
+		g, ctx := WithContext(parent)
+		g.Go(func() error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		cancel()
+
+		assert.ErrorIs(t, g.Wait(), context.Canceled)
+	})
+
+	t.Run("invalid: combined error includes every stack", func(t *testing.T) {
+		g, _ := WithContext(context.Background())
+		g.Go(func() error { return errors.New("returned") })
+		g.Go(func() error {
+			True(false, "asserted")
+			return nil
+		})
+
+		err := g.Wait()
+
+		assert.ElementsMatch(t, []string{"returned", "asserted"}, messages(err.(*GroupError)))
+		detailed := fmt.Sprintf("%+v", err)
+		assert.Contains(t, detailed, "TestGroup")
+		assert.Contains(t, detailed, "group_test.go:")
+	})
+
+	t.Run("invalid: foreign panic is re-raised by Wait", func(t *testing.T) {
+		g, ctx := WithContext(context.Background())
+		g.Go(func() error { panic("foreign") })
+
+		var r any
+		func() {
+			defer func() { r = recover() }()
+			g.Wait()
+		}()
+
+		panicErr, ok := r.(*PanicError)
+		assert.True(t, ok)
+		assert.Equal(t, "foreign", panicErr.Value)
+		assert.Contains(t, string(panicErr.Stack), "group_test.go")
+		assert.Error(t, ctx.Err())
+	})
+
+	t.Run("invalid: foreign error panic keeps the error chain", func(t *testing.T) {
+		var g Group
+		g.Go(func() error { panic(fs.ErrNotExist) })
+
+		func() {
+			defer func() {
+				assert.ErrorIs(t, recover().(error), fs.ErrNotExist)
+			}()
+			g.Wait()
+		}()
+	})
+}
+
+func messages(err *GroupError) []string {
+	result := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		result[i] = e.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,54 @@
+package must
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+)
+
+// Handler is called with the error every must function is about to panic
+// with. It may log, flush telemetry or exit the process; if it returns, the
+// panic proceeds.
+//
+// It runs for every failure, including the ones that Try, LazyValue.TryGet,
+// Group.Go, Main or a deferred Recover later turn into errors, since the
+// failing call cannot tell whether it will be recovered.
+type Handler func(err *Error)
+
+var handler atomic.Pointer[Handler]
+
+// SetHandler installs h, or removes the current handler if h is nil. The
+// returned function restores the previous handler, which makes swapping
+// handlers in tests a one-liner:
+//
+//	defer must.SetHandler(func(err *must.Error) { ... })()
+func SetHandler(h Handler) (restore func()) {
+	var next *Handler
+	if h != nil {
+		next = &h
+	}
+
+	prev := handler.Swap(next)
+	return func() {
+		handler.Store(prev)
+	}
+}
+
+// LogHandler returns a Handler that logs the error with its caller and
+// stack at error level.
+func LogHandler(logger *slog.Logger) Handler {
+	return func(err *Error) {
+		caller := err.Caller()
+		stack := make([]string, 0, len(err.Stack()))
+		for _, frame := range err.Stack() {
+			stack = append(stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		}
+
+		logger.LogAttrs(context.Background(), slog.LevelError, "must failed",
+			slog.String("error", err.Error()),
+			slog.String("caller", fmt.Sprintf("%s:%d", caller.File, caller.Line)),
+			slog.Any("stack", stack),
+		)
+	}
+}
@@ -0,0 +1,101 @@
+package must
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetHandler(t *testing.T) {
+	t.Run("valid: handler is called before panicking", func(t *testing.T) {
+		var handled *Error
+		defer SetHandler(func(err *Error) { handled = err })()
+
+		assert.PanicsWithError(t, "error message", func() { Must(0, errors.New("error message")) })
+		assert.EqualError(t, handled, "error message")
+		assert.NotEmpty(t, handled.Stack())
+	})
+
+	t.Run("valid: handler is not called on success", func(t *testing.T) {
+		called := false
+		defer SetHandler(func(*Error) { called = true })()
+
+		Must(0, nil)
+		MustBeOk(0, true)
+		True(true, "")
+
+		assert.False(t, called)
+	})
+
+	t.Run("valid: handler runs for failures converted to errors", func(t *testing.T) {
+		var calls atomic.Int32
+		defer SetHandler(func(*Error) { calls.Add(1) })()
+
+		_, err := Try(func() int { return Must(0, errors.New("try")) })
+		assert.Error(t, err)
+
+		_, err = Lazy(func() (int, error) { return Must(0, errors.New("lazy")), nil }).TryGet()
+		assert.Error(t, err)
+
+		var g Group
+		g.Go(func() error {
+			Do(errors.New("group"))
+			return nil
+		})
+		assert.Error(t, g.Wait())
+
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("valid: handler runs for a deferred Recover", func(t *testing.T) {
+		called := false
+		defer SetHandler(func(*Error) { called = true })()
+
+		err := func() (err error) {
+			defer Recover(&err)
+			Do(errors.New("recovered"))
+			return nil
+		}()
+
+		assert.Error(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("valid: handler can stop the panic", func(t *testing.T) {
+		type exitCode int
+		defer SetHandler(func(*Error) { panic(exitCode(3)) })()
+
+		assert.PanicsWithValue(t, exitCode(3), func() { Do(errors.New("error message")) })
+	})
+
+	t.Run("valid: restore brings back the previous handler", func(t *testing.T) {
+		var calls []string
+		defer SetHandler(func(*Error) { calls = append(calls, "outer") })()
+		SetHandler(func(*Error) { calls = append(calls, "inner") })()
+
+		assert.Panics(t, func() { True(false, "") })
+		assert.Equal(t, []string{"outer"}, calls)
+	})
+
+	t.Run("valid: nil removes the handler", func(t *testing.T) {
+		called := false
+		defer SetHandler(func(*Error) { called = true })()
+		defer SetHandler(nil)()
+
+		assert.Panics(t, func() { True(false, "") })
+		assert.False(t, called)
+	})
+}
+
+func TestLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	defer SetHandler(LogHandler(slog.New(slog.NewTextHandler(&buf, nil))))()
+
+	assert.Panics(t, func() { Must(0, errors.New("error message")) })
+	assert.Contains(t, buf.String(), `level=ERROR msg="must failed" error="error message" caller=`)
+	assert.Contains(t, buf.String(), "handler_test.go:")
+}
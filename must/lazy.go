@@ -0,0 +1,86 @@
+package must
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// LazyValue is initialized on first use instead of at import time. It is
+// safe for concurrent use, including Reset; init runs at most once until
+// Reset.
+type LazyValue[T any] struct {
+	init func() (T, error)
+
+	mu     sync.Mutex
+	result atomic.Pointer[lazyResult[T]]
+}
+
+// lazyResult is never modified once stored, so it can be read without the
+// lock.
+type lazyResult[T any] struct {
+	value T
+	err   error
+}
+
+// Lazy replaces package level initializations like
+//
+//	var db = must.Must(open())
+//
+// with
+//
+//	var db = must.Lazy(open)
+func Lazy[T any](init func() (T, error)) *LazyValue[T] {
+	return &LazyValue[T]{init: init}
+}
+
+// Get returns the value and panics like Must if the initialization failed.
+// A must panic in init is raised again as is, keeping its stack.
+func (l *LazyValue[T]) Get() T {
+	value, err := l.TryGet()
+	if err != nil {
+		var mustErr *Error
+		if !errors.As(err, &mustErr) {
+			mustErr = newError(1, err)
+		}
+		fail(mustErr)
+	}
+
+	return value
+}
+
+// TryGet returns the value or the error of the initialization. A failed
+// initialization is not retried until Reset.
+func (l *LazyValue[T]) TryGet() (T, error) {
+	if result := l.result.Load(); result != nil {
+		return result.value, result.err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	result := l.result.Load()
+	if result == nil {
+		result = &lazyResult[T]{}
+		result.value, result.err = l.call()
+		l.result.Store(result)
+	}
+
+	return result.value, result.err
+}
+
+// call runs init, converting a must panic inside it into its error.
+func (l *LazyValue[T]) call() (value T, err error) {
+	defer Recover(&err)
+
+	return l.init()
+}
+
+// Reset discards the value so that the next use initializes it again,
+// which is mostly useful in tests.
+func (l *LazyValue[T]) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.result.Store(nil)
+}
@@ -0,0 +1,131 @@
+package must
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLazy(t *testing.T) {
+	t.Run("valid: initializes once on first use", func(t *testing.T) {
+		calls := 0
+		l := Lazy(func() (int, error) {
+			calls++
+			return 10, nil
+		})
+
+		assert.Zero(t, calls)
+		assert.Equal(t, 10, l.Get())
+		assert.Equal(t, 10, l.Get())
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("valid: concurrent first use", func(t *testing.T) {
+		var calls atomic.Int32
+		l := Lazy(func() (int, error) {
+			calls.Add(1)
+			return 10, nil
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.Equal(t, 10, l.Get())
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("invalid: TryGet returns the error without retrying", func(t *testing.T) {
+		errOpen := errors.New("open failed")
+		calls := 0
+		l := Lazy(func() (int, error) {
+			calls++
+			return 0, errOpen
+		})
+
+		_, err := l.TryGet()
+		assert.ErrorIs(t, err, errOpen)
+		_, err = l.TryGet()
+		assert.ErrorIs(t, err, errOpen)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("invalid: Get panics with typed error at the caller", func(t *testing.T) {
+		errOpen := errors.New("open failed")
+		l := Lazy(func() (int, error) { return 0, errOpen })
+
+		_, _, line, _ := runtime.Caller(0)
+		err := recoverError(func() { l.Get() })
+
+		assert.ErrorIs(t, err, errOpen)
+		assert.Equal(t, line+1, err.Caller().Line)
+	})
+
+	t.Run("invalid: must panic in init is captured", func(t *testing.T) {
+		l := Lazy(func() (int, error) {
+			True(false, "not configured")
+			return 0, nil
+		})
+
+		_, err := l.TryGet()
+
+		assert.EqualError(t, err, "not configured")
+	})
+
+	t.Run("invalid: Get keeps the stack of a must panic in init", func(t *testing.T) {
+		var line int
+		l := Lazy(func() (int, error) {
+			_, _, line, _ = runtime.Caller(0)
+			True(false, "not configured")
+			return 0, nil
+		})
+
+		err := recoverError(func() { l.Get() })
+
+		assert.EqualError(t, err, "not configured")
+		assert.Equal(t, line+1, err.Caller().Line)
+	})
+
+	t.Run("valid: reset initializes again", func(t *testing.T) {
+		value := 1
+		l := Lazy(func() (int, error) { return value, nil })
+
+		assert.Equal(t, 1, l.Get())
+		value = 2
+		assert.Equal(t, 1, l.Get())
+
+		l.Reset()
+		assert.Equal(t, 2, l.Get())
+	})
+
+	t.Run("valid: reset concurrently with get", func(t *testing.T) {
+		l := Lazy(func() (int, error) { return 10, nil })
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					assert.Equal(t, 10, l.Get())
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					l.Reset()
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
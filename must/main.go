@@ -0,0 +1,108 @@
+package must
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// DebugEnvKey enables full stack output in Main when set to a non-empty value.
+const DebugEnvKey = "GODEVKIT_DEBUG"
+
+// ExitCodeInterrupted is used when run returns after the context was
+// cancelled by a signal.
+const ExitCodeInterrupted = 130
+
+// ExitCoder is implemented by errors that choose the exit code of Main.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+type exitError struct {
+	err  error
+	code int
+}
+
+// WithExitCode attaches an exit code to err for Main.
+func WithExitCode(err error, code int) error {
+	return &exitError{err: err, code: code}
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+func (e *exitError) ExitCode() int {
+	return e.code
+}
+
+// Runner runs a main function. Main uses a Runner that exits the process,
+// writes to stderr and cancels on SIGINT and SIGTERM; tests can build their
+// own.
+type Runner struct {
+	Exit    func(code int)
+	Output  io.Writer
+	Signals []os.Signal
+	Debug   bool
+}
+
+// Main runs run with a context cancelled on SIGINT or SIGTERM. A returned
+// error or must panic is printed as a single line, or with its stack when
+// DebugEnvKey is set, and the process exits with the code chosen by an
+// ExitCoder in the error chain, ExitCodeInterrupted after a signal, or 1.
+func Main(run func(ctx context.Context) error) {
+	Runner{
+		Exit:    os.Exit,
+		Output:  os.Stderr,
+		Signals: []os.Signal{os.Interrupt, syscall.SIGTERM},
+		Debug:   os.Getenv(DebugEnvKey) != "",
+	}.Run(run)
+}
+
+func (r Runner) Run(run func(ctx context.Context) error) {
+	ctx, stop := context.WithCancel(context.Background())
+	if len(r.Signals) > 0 {
+		ctx, stop = signal.NotifyContext(ctx, r.Signals...)
+	}
+	defer stop()
+
+	err := r.call(ctx, run)
+	if err == nil {
+		return
+	}
+
+	if r.Debug {
+		fmt.Fprintf(r.Output, "error: %+v\n", err)
+	} else {
+		fmt.Fprintf(r.Output, "error: %v\n", err)
+	}
+
+	r.Exit(exitCode(ctx, err))
+}
+
+func (r Runner) call(ctx context.Context, run func(ctx context.Context) error) (err error) {
+	defer Recover(&err)
+
+	return run(ctx)
+}
+
+func exitCode(ctx context.Context, err error) int {
+	var coder ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+
+	if ctx.Err() != nil && errors.Is(err, context.Canceled) {
+		return ExitCodeInterrupted
+	}
+
+	return 1
+}
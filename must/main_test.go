@@ -0,0 +1,85 @@
+package must
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRunner(debug bool) (Runner, *bytes.Buffer, *[]int) {
+	var out bytes.Buffer
+	var codes []int
+
+	return Runner{
+		Exit:   func(code int) { codes = append(codes, code) },
+		Output: &out,
+		Debug:  debug,
+	}, &out, &codes
+}
+
+func TestRunner(t *testing.T) {
+	t.Run("valid: success does not exit", func(t *testing.T) {
+		r, out, codes := newTestRunner(false)
+
+		r.Run(func(context.Context) error { return nil })
+
+		assert.Empty(t, *codes)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("invalid: returned error exits with 1", func(t *testing.T) {
+		r, out, codes := newTestRunner(false)
+
+		r.Run(func(context.Context) error { return errors.New("config not found") })
+
+		assert.Equal(t, []int{1}, *codes)
+		assert.Equal(t, "error: config not found\n", out.String())
+	})
+
+	t.Run("invalid: exit code from error chain", func(t *testing.T) {
+		r, _, codes := newTestRunner(false)
+
+		r.Run(func(context.Context) error {
+			return fmt.Errorf("loading: %w", WithExitCode(errors.New("bad config"), 78))
+		})
+
+		assert.Equal(t, []int{78}, *codes)
+	})
+
+	t.Run("invalid: must panic is printed concisely", func(t *testing.T) {
+		r, out, codes := newTestRunner(false)
+
+		r.Run(func(context.Context) error {
+			Must(0, WithExitCode(errors.New("bad config"), 78))
+			return nil
+		})
+
+		assert.Equal(t, []int{78}, *codes)
+		assert.Equal(t, "error: bad config\n", out.String())
+	})
+
+	t.Run("invalid: debug prints the stack", func(t *testing.T) {
+		r, out, _ := newTestRunner(true)
+
+		r.Run(func(context.Context) error {
+			Do(errors.New("bad config"))
+			return nil
+		})
+
+		assert.Contains(t, out.String(), "error: bad config\n")
+		assert.Contains(t, out.String(), "main_test.go:")
+	})
+
+	t.Run("invalid: foreign panics are not recovered", func(t *testing.T) {
+		r, _, _ := newTestRunner(false)
+
+		assert.PanicsWithValue(t, "foreign", func() {
+			r.Run(func(context.Context) error { panic("foreign") })
+		})
+	})
+
+}
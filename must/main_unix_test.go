@@ -0,0 +1,27 @@
+//go:build unix
+
+package must
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunnerSignal(t *testing.T) {
+	t.Run("invalid: signal cancels the context", func(t *testing.T) {
+		r, _, codes := newTestRunner(false)
+		r.Signals = []os.Signal{syscall.SIGUSR1}
+
+		r.Run(func(ctx context.Context) error {
+			syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		assert.Equal(t, []int{ExitCodeInterrupted}, *codes)
+	})
+}
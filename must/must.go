@@ -1,15 +1,57 @@
 package must
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+)
 
 func Must[T any](obj T, err error) T {
 	if err != nil {
-		panic(err)
+		fail(newError(1, err))
 	}
 
 	return obj
 }
 
+func Must2[T1 any, T2 any](obj1 T1, obj2 T2, err error) (T1, T2) {
+	if err != nil {
+		fail(newError(1, err))
+	}
+
+	return obj1, obj2
+}
+
+func Must3[T1 any, T2 any, T3 any](obj1 T1, obj2 T2, obj3 T3, err error) (T1, T2, T3) {
+	if err != nil {
+		fail(newError(1, err))
+	}
+
+	return obj1, obj2, obj3
+}
+
+func Do(err error) {
+	if err != nil {
+		fail(newError(1, err))
+	}
+}
+
+// Mustf is Must with the error wrapped as "<formatted message>: <err>".
+// The message is only formatted when err is not nil.
+func Mustf[T any](obj T, err error, format string, args ...any) T {
+	if err != nil {
+		fail(newError(1, wrapf(err, format, args...)))
+	}
+
+	return obj
+}
+
+// Dof is Do with the error wrapped as "<formatted message>: <err>".
+func Dof(err error, format string, args ...any) {
+	if err != nil {
+		fail(newError(1, wrapf(err, format, args...)))
+	}
+}
+
 func MustBeOk[T any](value T, ok bool, args ...error) T {
 	return assertCondition(value, ok, "value must be ok", args...)
 }
@@ -20,24 +62,24 @@ func MustNotBeOk[T any](value T, ok bool, args ...error) T {
 
 func assertCondition[T any](value T, condition bool, defaultMsg string, args ...error) T {
 	if !condition {
-		panic(buildErrorMessage(defaultMsg, args...))
+		if len(args) == 0 {
+			args = []error{errors.New(defaultMsg)}
+		}
+
+		fail(newError(2, args...))
 	}
 
 	return value
 }
 
-func buildErrorMessage(defaultErrorMessage string, args ...error) string {
-	if len(args) == 0 {
-		return defaultErrorMessage
-	}
+func wrapf(err error, format string, args ...any) error {
+	return fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err)
+}
 
-	var sb strings.Builder
-	for i, err := range args {
-		sb.WriteString(err.Error())
-		if len(args) > 1 && len(args)-1 != i {
-			sb.WriteString("; ")
-		}
+func fail(err *Error) {
+	if h := handler.Load(); h != nil {
+		(*h)(err)
 	}
 
-	return sb.String()
+	panic(err)
 }
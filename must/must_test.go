@@ -49,13 +49,13 @@ func TestBeOk(t *testing.T) {
 	})
 
 	t.Run("invalid: with error", func(t *testing.T) {
-		assert.PanicsWithValue(t, "error message", func() {
+		assert.PanicsWithError(t, "error message", func() {
 			MustBeOk("valid string", false, errors.New("error message"))
 		})
 	})
 
 	t.Run("invalid: more than 1 error", func(t *testing.T) {
-		assert.PanicsWithValue(t, "error message; second error message", func() {
+		assert.PanicsWithError(t, "error message; second error message", func() {
 			MustBeOk("valid string", false, errors.New("error message"), errors.New("second error message"))
 		})
 	})
@@ -75,14 +75,84 @@ func TestNotBeOk(t *testing.T) {
 	})
 
 	t.Run("invalid: with error", func(t *testing.T) {
-		assert.PanicsWithValue(t, "error message", func() {
+		assert.PanicsWithError(t, "error message", func() {
 			MustNotBeOk("valid string", true, errors.New("error message"))
 		})
 	})
 
 	t.Run("invalid: more than 1 error", func(t *testing.T) {
-		assert.PanicsWithValue(t, "error message; second error message", func() {
+		assert.PanicsWithError(t, "error message; second error message", func() {
 			MustNotBeOk("valid string", true, errors.New("error message"), errors.New("second error message"))
 		})
 	})
 }
+
+func TestMust2(t *testing.T) {
+	t.Run("valid: no error", func(t *testing.T) {
+		a, b := Must2(1, "two", nil)
+
+		assert.Equal(t, 1, a)
+		assert.Equal(t, "two", b)
+	})
+
+	t.Run("invalid: with error", func(t *testing.T) {
+		assert.PanicsWithError(t, "error message", func() {
+			Must2(1, "two", errors.New("error message"))
+		})
+	})
+}
+
+func TestMust3(t *testing.T) {
+	t.Run("valid: no error", func(t *testing.T) {
+		a, b, c := Must3(1, "two", 3.0, nil)
+
+		assert.Equal(t, 1, a)
+		assert.Equal(t, "two", b)
+		assert.Equal(t, 3.0, c)
+	})
+
+	t.Run("invalid: with error", func(t *testing.T) {
+		assert.PanicsWithError(t, "error message", func() {
+			Must3(1, "two", 3.0, errors.New("error message"))
+		})
+	})
+}
+
+func TestDo(t *testing.T) {
+	t.Run("valid: no error", func(t *testing.T) {
+		assert.NotPanics(t, func() { Do(nil) })
+	})
+
+	t.Run("invalid: with error", func(t *testing.T) {
+		assert.PanicsWithError(t, "error message", func() {
+			Do(errors.New("error message"))
+		})
+	})
+}
+
+func TestMustf(t *testing.T) {
+	t.Run("valid: no error", func(t *testing.T) {
+		assert.Equal(t, "value", Mustf("value", nil, "loading %s", "config.yaml"))
+	})
+
+	t.Run("invalid: error is wrapped with context", func(t *testing.T) {
+		cause := errors.New("error message")
+
+		err := recoverError(func() { Mustf("value", cause, "loading %s", "config.yaml") })
+
+		assert.EqualError(t, err, "loading config.yaml: error message")
+		assert.ErrorIs(t, err, cause)
+	})
+}
+
+func TestDof(t *testing.T) {
+	t.Run("valid: no error", func(t *testing.T) {
+		assert.NotPanics(t, func() { Dof(nil, "creating %s", "dir") })
+	})
+
+	t.Run("invalid: error is wrapped with context", func(t *testing.T) {
+		assert.PanicsWithError(t, "creating dir: error message", func() {
+			Dof(errors.New("error message"), "creating %s", "dir")
+		})
+	})
+}
@@ -0,0 +1,31 @@
+package must
+
+// Try calls fn and returns the *Error it panicked with, if any. Panics
+// that did not originate from this package are propagated unchanged.
+func Try[T any](fn func() T) (value T, err error) {
+	defer Recover(&err)
+
+	return fn(), nil
+}
+
+// Recover stores a recovered *Error in err. It must be deferred directly:
+//
+//	func load() (err error) {
+//		defer must.Recover(&err)
+//		...
+//	}
+//
+// Panics that did not originate from this package are re-panicked.
+func Recover(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	mustErr, ok := r.(*Error)
+	if !ok {
+		panic(r)
+	}
+
+	*err = mustErr
+}
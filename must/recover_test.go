@@ -0,0 +1,69 @@
+package must
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTry(t *testing.T) {
+	t.Run("valid: returns value without panic", func(t *testing.T) {
+		value, err := Try(func() int { return Must(10, nil) })
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, value)
+	})
+
+	t.Run("valid: converts must panic into error", func(t *testing.T) {
+		value, err := Try(func() int { return Must(10, fs.ErrNotExist) })
+
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		assert.Zero(t, value)
+
+		var mustErr *Error
+		assert.True(t, errors.As(err, &mustErr))
+	})
+
+	t.Run("invalid: re-panics on foreign panic", func(t *testing.T) {
+		assert.PanicsWithValue(t, "foreign", func() {
+			Try(func() int { panic("foreign") })
+		})
+	})
+
+	t.Run("invalid: re-panics on nil dereference", func(t *testing.T) {
+		assert.Panics(t, func() {
+			Try(func() int {
+				var p *int
+				return *p
+			})
+		})
+	})
+}
+
+func TestRecover(t *testing.T) {
+	load := func(fail bool) (err error) {
+		defer Recover(&err)
+
+		MustBeOk(0, !fail, errors.New("load failed"))
+		return nil
+	}
+
+	t.Run("valid: no panic keeps nil error", func(t *testing.T) {
+		assert.NoError(t, load(false))
+	})
+
+	t.Run("valid: must panic becomes returned error", func(t *testing.T) {
+		assert.EqualError(t, load(true), "load failed")
+	})
+
+	t.Run("invalid: re-panics on foreign panic", func(t *testing.T) {
+		assert.PanicsWithError(t, "foreign", func() {
+			func() (err error) {
+				defer Recover(&err)
+				panic(errors.New("foreign"))
+			}()
+		})
+	})
+}
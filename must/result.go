@@ -0,0 +1,81 @@
+package must
+
+// Result holds either a value or the error that prevented producing it.
+//
+//	cfg, err := must.AndThen(must.AndThen(must.From(os.ReadFile(path)), parse), validate).Get()
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+func From[T any](value T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+
+	return Ok(value)
+}
+
+// Map applies fn to the value of an ok result.
+func Map[T any, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+
+	return Ok(fn(r.value))
+}
+
+// AndThen applies the fallible fn to the value of an ok result.
+func AndThen[T any, U any](r Result[T], fn func(T) (U, error)) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+
+	return From(fn(r.value))
+}
+
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// OrElse replaces a failed result with the one returned by fn.
+func (r Result[T]) OrElse(fn func(error) Result[T]) Result[T] {
+	if r.err != nil {
+		return fn(r.err)
+	}
+
+	return r
+}
+
+func (r Result[T]) UnwrapOr(defaultValue T) T {
+	if r.err != nil {
+		return defaultValue
+	}
+
+	return r.value
+}
+
+// Must returns the value or panics like Must with the error.
+func (r Result[T]) Must() T {
+	if r.err != nil {
+		fail(newError(1, r.err))
+	}
+
+	return r.value
+}
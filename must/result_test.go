@@ -0,0 +1,70 @@
+package must
+
+import (
+	"errors"
+	"io/fs"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	t.Run("valid: Ok and Err", func(t *testing.T) {
+		assert.True(t, Ok(1).IsOk())
+		assert.False(t, Err[int](errBoom).IsOk())
+		assert.Equal(t, errBoom, Err[int](errBoom).Err())
+	})
+
+	t.Run("valid: From", func(t *testing.T) {
+		value, err := From(1, nil).Get()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, value)
+
+		_, err = From(1, errBoom).Get()
+		assert.ErrorIs(t, err, errBoom)
+	})
+
+	t.Run("valid: Map", func(t *testing.T) {
+		double := func(v int) int { return v * 2 }
+
+		assert.Equal(t, 4, Map(Ok(2), double).Must())
+		assert.ErrorIs(t, Map(Err[int](errBoom), double).Err(), errBoom)
+	})
+
+	t.Run("valid: AndThen pipeline", func(t *testing.T) {
+		validate := func(v int) (int, error) {
+			if v <= 0 {
+				return 0, errors.New("must be positive")
+			}
+			return v, nil
+		}
+
+		assert.Equal(t, 8080, AndThen(AndThen(Ok("8080"), strconv.Atoi), validate).Must())
+		assert.EqualError(t, AndThen(AndThen(Ok("-1"), strconv.Atoi), validate).Err(), "must be positive")
+		assert.ErrorIs(t, AndThen(Err[string](fs.ErrNotExist), strconv.Atoi).Err(), fs.ErrNotExist)
+	})
+
+	t.Run("valid: OrElse", func(t *testing.T) {
+		fallback := func(err error) Result[int] { return Ok(10) }
+
+		assert.Equal(t, 1, Ok(1).OrElse(fallback).Must())
+		assert.Equal(t, 10, Err[int](errBoom).OrElse(fallback).Must())
+	})
+
+	t.Run("valid: UnwrapOr", func(t *testing.T) {
+		assert.Equal(t, 1, Ok(1).UnwrapOr(10))
+		assert.Equal(t, 10, Err[int](errBoom).UnwrapOr(10))
+	})
+
+	t.Run("invalid: Must panics with typed error", func(t *testing.T) {
+		_, _, line, _ := runtime.Caller(0)
+		err := recoverError(func() { Err[int](errBoom).Must() })
+
+		assert.ErrorIs(t, err, errBoom)
+		assert.Equal(t, line+1, err.Caller().Line)
+	})
+}
@@ -0,0 +1,13 @@
+package execiface
+
+import (
+	"context"
+
+	"github.com/4rchr4y/godevkit/v3/syswrap"
+)
+
+type ExecWrapper interface {
+	Output(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+var _ ExecWrapper = (*syswrap.ExecWrap)(nil)
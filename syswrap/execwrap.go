@@ -0,0 +1,12 @@
+package syswrap
+
+import (
+	"context"
+	"os/exec"
+)
+
+type ExecWrap struct{}
+
+func (ExecWrap) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output()
+}
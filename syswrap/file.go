@@ -0,0 +1,24 @@
+package syswrap
+
+import (
+	"io"
+	"io/fs"
+)
+
+// File is the subset of *os.File returned by the OS wrappers, so that
+// implementations other than the real filesystem can provide files.
+type File interface {
+	io.Reader
+	io.ReaderAt
+	io.Writer
+	io.StringWriter
+	io.Seeker
+	io.Closer
+
+	Name() string
+	Stat() (fs.FileInfo, error)
+	ReadDir(n int) ([]fs.DirEntry, error)
+	Readdirnames(n int) ([]string, error)
+	Sync() error
+	Truncate(size int64) error
+}
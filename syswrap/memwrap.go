@@ -0,0 +1,837 @@
+package syswrap
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// memUmask mirrors the usual process umask, so that permissions reported by
+// MemOSWrap match the ones created on a real filesystem.
+const memUmask fs.FileMode = 0o022
+
+// MemOSWrap is an in-memory implementation of the OS wrapper. Relative paths
+// are resolved against the working directory, "/" by default. Permissions are
+// recorded but not enforced, and volume names are ignored.
+type MemOSWrap struct {
+	mu   sync.Mutex
+	root *memNode
+	wd   string
+	home string
+	env  map[string]string
+	now  func() time.Time
+}
+
+type memNode struct {
+	mode     fs.FileMode
+	modTime  time.Time
+	data     []byte
+	children map[string]*memNode
+}
+
+func NewMemOSWrap() *MemOSWrap {
+	m := &MemOSWrap{
+		wd:  string(filepath.Separator),
+		env: make(map[string]string),
+		now: time.Now,
+	}
+	m.root = m.newDir(fs.ModePerm)
+
+	return m
+}
+
+func (m *MemOSWrap) Setenv(key string, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.env[key] = value
+}
+
+func (m *MemOSWrap) SetUserHomeDir(dir string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.home = dir
+}
+
+// SetClock replaces the source of modification times.
+func (m *MemOSWrap) SetClock(now func() time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = now
+}
+
+func (m *MemOSWrap) Chdir(dir string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, err := m.lookup(dir)
+	if err == nil && !node.isDir() {
+		err = syscall.ENOTDIR
+	}
+	if err != nil {
+		return &fs.PathError{Op: "chdir", Path: dir, Err: err}
+	}
+
+	m.wd = m.abs(dir)
+	return nil
+}
+
+func (m *MemOSWrap) LookupEnv(key string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.env[key]
+	return value, ok
+}
+
+func (m *MemOSWrap) Getwd() (dir string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.wd, nil
+}
+
+func (m *MemOSWrap) UserHomeDir() (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.home == "" {
+		return "", errors.New("home directory is not defined")
+	}
+
+	return m.home, nil
+}
+
+func (m *MemOSWrap) Rename(oldpath string, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.rename(oldpath, newpath); err != nil {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: err}
+	}
+
+	return nil
+}
+
+func (m *MemOSWrap) rename(oldpath string, newpath string) error {
+	oldDir, oldBase, err := m.lookupParent(oldpath)
+	if err != nil {
+		return err
+	}
+	if oldBase == "" {
+		return syscall.EBUSY
+	}
+
+	node, ok := oldDir.children[oldBase]
+	if !ok {
+		return fs.ErrNotExist
+	}
+
+	newDir, newBase, err := m.lookupParent(newpath)
+	if err != nil {
+		return err
+	}
+	if newBase == "" {
+		return syscall.EBUSY
+	}
+
+	oldAbs, newAbs := m.abs(oldpath), m.abs(newpath)
+	if oldAbs == newAbs {
+		return nil
+	}
+	if node.isDir() && strings.HasPrefix(newAbs, oldAbs+string(filepath.Separator)) {
+		return syscall.EINVAL
+	}
+
+	if target, ok := newDir.children[newBase]; ok {
+		if target.isDir() {
+			return fs.ErrExist
+		}
+		if node.isDir() {
+			return syscall.ENOTDIR
+		}
+	}
+
+	now := m.now()
+	delete(oldDir.children, oldBase)
+	newDir.children[newBase] = node
+	oldDir.modTime, newDir.modTime = now, now
+
+	return nil
+}
+
+func (m *MemOSWrap) Walk(root string, fn filepath.WalkFunc) error {
+	info, err := m.Lstat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = m.walk(root, info, fn)
+	}
+
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	}
+	return err
+}
+
+// walk follows filepath.Walk: entries are visited in lexical order and the
+// lock is never held while fn runs, so fn may modify the tree.
+func (m *MemOSWrap) walk(path string, info fs.FileInfo, fn filepath.WalkFunc) error {
+	if !info.IsDir() {
+		return fn(path, info, nil)
+	}
+
+	entries, err := m.readDir(path)
+	if err1 := fn(path, info, err); err != nil || err1 != nil {
+		return err1
+	}
+
+	for _, entry := range entries {
+		name := filepath.Join(path, entry.Name())
+
+		info, err := m.Lstat(name)
+		if err != nil {
+			if err := fn(name, info, err); err != nil && err != filepath.SkipDir {
+				return err
+			}
+			continue
+		}
+
+		if err := m.walk(name, info, fn); err != nil {
+			if !info.IsDir() || err != filepath.SkipDir {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *MemOSWrap) WalkDir(root string, fn fs.WalkDirFunc) error {
+	info, err := m.Lstat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = m.walkDir(root, fs.FileInfoToDirEntry(info), fn)
+	}
+
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	}
+	return err
+}
+
+// walkDir follows filepath.WalkDir.
+func (m *MemOSWrap) walkDir(path string, entry fs.DirEntry, fn fs.WalkDirFunc) error {
+	if err := fn(path, entry, nil); err != nil || !entry.IsDir() {
+		if err == filepath.SkipDir && entry.IsDir() {
+			err = nil
+		}
+		return err
+	}
+
+	entries, err := m.readDir(path)
+	if err != nil {
+		if err := fn(path, entry, err); err != nil {
+			if err == filepath.SkipDir {
+				err = nil
+			}
+			return err
+		}
+	}
+
+	for _, child := range entries {
+		if err := m.walkDir(filepath.Join(path, child.Name()), child, fn); err != nil {
+			if err == filepath.SkipDir {
+				break
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *MemOSWrap) readDir(name string) ([]fs.DirEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, err := m.lookup(name)
+	if err == nil && !node.isDir() {
+		err = syscall.ENOTDIR
+	}
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return node.entries(), nil
+}
+
+func (m *MemOSWrap) Mkdir(name string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dir, base, err := m.lookupParent(name)
+	if err == nil && (base == "" || dir.children[base] != nil) {
+		err = fs.ErrExist
+	}
+	if err != nil {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: err}
+	}
+
+	m.link(dir, base, m.newDir(perm))
+	return nil
+}
+
+func (m *MemOSWrap) MkdirAll(path string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	parts, err := m.split(path)
+	if err != nil {
+		return &fs.PathError{Op: "mkdir", Path: path, Err: err}
+	}
+
+	dir := m.root
+	for _, part := range parts {
+		child, ok := dir.children[part]
+		if !ok {
+			child = m.newDir(perm)
+			m.link(dir, part, child)
+		}
+		if !child.isDir() {
+			return &fs.PathError{Op: "mkdir", Path: path, Err: syscall.ENOTDIR}
+		}
+
+		dir = child
+	}
+
+	return nil
+}
+
+func (m *MemOSWrap) CreateFile(name string) (File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, err := m.create(name, 0o666)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &memFile{fs: m, name: name, node: node, writable: true}, nil
+}
+
+func (m *MemOSWrap) DeleteFile(filename string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dir, base, err := m.lookupParent(filename)
+	if err == nil && base == "" {
+		err = syscall.EBUSY
+	}
+	if err != nil {
+		return &fs.PathError{Op: "remove", Path: filename, Err: err}
+	}
+
+	node, ok := dir.children[base]
+	if !ok {
+		return &fs.PathError{Op: "remove", Path: filename, Err: fs.ErrNotExist}
+	}
+	if len(node.children) > 0 {
+		return &fs.PathError{Op: "remove", Path: filename, Err: syscall.ENOTEMPTY}
+	}
+
+	delete(dir.children, base)
+	dir.modTime = m.now()
+
+	return nil
+}
+
+func (m *MemOSWrap) OpenFile(name string) (File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, err := m.lookup(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &memFile{fs: m, name: name, node: node}, nil
+}
+
+func (m *MemOSWrap) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, err := m.lookup(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	if node.isDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: syscall.EISDIR}
+	}
+
+	return append([]byte{}, node.data...), nil
+}
+
+func (m *MemOSWrap) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, err := m.create(name, perm)
+	if err != nil {
+		return &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	node.data = append([]byte{}, data...)
+	return nil
+}
+
+func (m *MemOSWrap) MoveFile(source string, target string) error {
+	return m.Rename(source, target)
+}
+
+func (m *MemOSWrap) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, err := m.lookup(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+
+	return node.info(filepath.Base(name)), nil
+}
+
+// Lstat is Stat, MemOSWrap has no symbolic links.
+func (m *MemOSWrap) Lstat(path string) (fs.FileInfo, error) {
+	info, err := m.Stat(path)
+	if err != nil {
+		err.(*fs.PathError).Op = "lstat"
+	}
+
+	return info, err
+}
+
+func (m *MemOSWrap) Exists(path string) (bool, error) {
+	_, err := m.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	return false, err
+}
+
+func (m *MemOSWrap) DirExists(path string) bool {
+	fi, err := m.Lstat(path)
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode().IsDir()
+}
+
+func (m *MemOSWrap) FileExists(path string) bool {
+	fi, err := m.Lstat(path)
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode().IsRegular()
+}
+
+func (m *MemOSWrap) DirIsEmpty(dir string) (bool, error) {
+	f, err := m.OpenFile(dir)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	_, err = f.Readdirnames(1)
+	if err == io.EOF {
+		return true, nil
+	}
+	return false, err
+}
+
+func (*MemOSWrap) ReadGzip(reader io.Reader) (*gzip.Reader, error) {
+	return gzip.NewReader(reader)
+}
+
+func (*MemOSWrap) WriteGzip(writer io.Writer) *gzip.Writer {
+	return gzip.NewWriter(writer)
+}
+
+func (*MemOSWrap) ReadTar(reader io.Reader) *tar.Reader {
+	return tar.NewReader(reader)
+}
+
+func (*MemOSWrap) WriteTar(writer io.Writer) *tar.Writer {
+	return tar.NewWriter(writer)
+}
+
+func (m *MemOSWrap) abs(name string) string {
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(m.wd, name)
+	}
+
+	return filepath.Clean(name)
+}
+
+func (m *MemOSWrap) split(name string) ([]string, error) {
+	if name == "" {
+		return nil, fs.ErrNotExist
+	}
+
+	name = m.abs(name)
+	name = strings.Trim(name[len(filepath.VolumeName(name)):], string(filepath.Separator))
+	if name == "" {
+		return nil, nil
+	}
+
+	return strings.Split(name, string(filepath.Separator)), nil
+}
+
+func (m *MemOSWrap) lookup(name string) (*memNode, error) {
+	parts, err := m.split(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.walkParts(parts)
+}
+
+// lookupParent returns the directory that holds name and the base name, which
+// is empty for the root.
+func (m *MemOSWrap) lookupParent(name string) (*memNode, string, error) {
+	parts, err := m.split(name)
+	if err != nil || len(parts) == 0 {
+		return m.root, "", err
+	}
+
+	dir, err := m.walkParts(parts[:len(parts)-1])
+	if err == nil && !dir.isDir() {
+		err = syscall.ENOTDIR
+	}
+
+	return dir, parts[len(parts)-1], err
+}
+
+func (m *MemOSWrap) walkParts(parts []string) (*memNode, error) {
+	node := m.root
+	for _, part := range parts {
+		if !node.isDir() {
+			return nil, syscall.ENOTDIR
+		}
+
+		child, ok := node.children[part]
+		if !ok {
+			return nil, fs.ErrNotExist
+		}
+		node = child
+	}
+
+	return node, nil
+}
+
+// create truncates an existing file or creates a new one with perm.
+func (m *MemOSWrap) create(name string, perm fs.FileMode) (*memNode, error) {
+	dir, base, err := m.lookupParent(name)
+	if err != nil {
+		return nil, err
+	}
+	if base == "" {
+		return nil, syscall.EISDIR
+	}
+
+	if node, ok := dir.children[base]; ok {
+		if node.isDir() {
+			return nil, syscall.EISDIR
+		}
+
+		node.data, node.modTime = nil, m.now()
+		return node, nil
+	}
+
+	node := &memNode{mode: perm & fs.ModePerm &^ memUmask, modTime: m.now()}
+	m.link(dir, base, node)
+
+	return node, nil
+}
+
+func (m *MemOSWrap) newDir(perm fs.FileMode) *memNode {
+	return &memNode{
+		mode:     fs.ModeDir | perm&fs.ModePerm&^memUmask,
+		modTime:  m.now(),
+		children: make(map[string]*memNode),
+	}
+}
+
+func (m *MemOSWrap) link(dir *memNode, name string, node *memNode) {
+	dir.children[name] = node
+	dir.modTime = m.now()
+}
+
+func (n *memNode) isDir() bool {
+	return n.mode.IsDir()
+}
+
+func (n *memNode) info(name string) fs.FileInfo {
+	return &memFileInfo{name: name, size: int64(len(n.data)), mode: n.mode, modTime: n.modTime}
+}
+
+func (n *memNode) entries() []fs.DirEntry {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := make([]fs.DirEntry, len(names))
+	for i, name := range names {
+		entries[i] = fs.FileInfoToDirEntry(n.children[name].info(name))
+	}
+
+	return entries
+}
+
+type memFileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (fi *memFileInfo) Name() string       { return fi.name }
+func (fi *memFileInfo) Size() int64        { return fi.size }
+func (fi *memFileInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi *memFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *memFileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi *memFileInfo) Sys() any           { return nil }
+
+// memFile is an open handle. Like on a real filesystem, it keeps working on
+// its node after the path is renamed or removed.
+type memFile struct {
+	fs       *MemOSWrap
+	name     string
+	node     *memNode
+	offset   int64
+	writable bool
+	closed   bool
+	dir      []fs.DirEntry
+	dirRead  bool
+}
+
+func (f *memFile) Name() string {
+	return f.name
+}
+
+func (f *memFile) Read(p []byte) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	n, err := f.readAt("read", p, f.offset)
+	f.offset += int64(n)
+
+	return n, err
+}
+
+func (f *memFile) ReadAt(p []byte, off int64) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	if off < 0 {
+		return 0, &fs.PathError{Op: "readat", Path: f.name, Err: errors.New("negative offset")}
+	}
+
+	n, err := f.readAt("read", p, off)
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+
+	return n, err
+}
+
+func (f *memFile) readAt(op string, p []byte, off int64) (int, error) {
+	if err := f.check(op, false); err != nil {
+		return 0, err
+	}
+	if f.node.isDir() {
+		return 0, &fs.PathError{Op: op, Path: f.name, Err: syscall.EISDIR}
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if off >= int64(len(f.node.data)) {
+		return 0, io.EOF
+	}
+
+	return copy(p, f.node.data[off:]), nil
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	if err := f.check("write", true); err != nil {
+		return 0, err
+	}
+
+	if end := f.offset + int64(len(p)); end > int64(len(f.node.data)) {
+		f.node.data = append(f.node.data, make([]byte, end-int64(len(f.node.data)))...)
+	}
+	n := copy(f.node.data[f.offset:], p)
+	f.offset += int64(n)
+	f.node.modTime = f.fs.now()
+
+	return n, nil
+}
+
+func (f *memFile) WriteString(s string) (int, error) {
+	return f.Write([]byte(s))
+}
+
+func (f *memFile) Seek(offset int64, whence int) (int64, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	if err := f.check("seek", false); err != nil {
+		return 0, err
+	}
+
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += int64(len(f.node.data))
+	case io.SeekStart:
+	default:
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: syscall.EINVAL}
+	}
+	if offset < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: syscall.EINVAL}
+	}
+
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *memFile) Close() error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	if err := f.check("close", false); err != nil {
+		return err
+	}
+
+	f.closed = true
+	return nil
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	if err := f.check("stat", false); err != nil {
+		return nil, err
+	}
+
+	return f.node.info(filepath.Base(f.name)), nil
+}
+
+func (f *memFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	if err := f.check("readdirent", false); err != nil {
+		return nil, err
+	}
+	if !f.node.isDir() {
+		return nil, &fs.PathError{Op: "readdirent", Path: f.name, Err: syscall.ENOTDIR}
+	}
+
+	if !f.dirRead {
+		f.dir, f.dirRead = f.node.entries(), true
+	}
+
+	if n <= 0 {
+		entries := f.dir
+		f.dir = nil
+		return entries, nil
+	}
+
+	if len(f.dir) == 0 {
+		return nil, io.EOF
+	}
+
+	n = min(n, len(f.dir))
+	entries := f.dir[:n:n]
+	f.dir = f.dir[n:]
+
+	return entries, nil
+}
+
+func (f *memFile) Readdirnames(n int) ([]string, error) {
+	entries, err := f.ReadDir(n)
+
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+
+	return names, err
+}
+
+func (f *memFile) Sync() error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	return f.check("sync", false)
+}
+
+func (f *memFile) Truncate(size int64) error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	if err := f.check("truncate", true); err != nil {
+		return err
+	}
+	if size < 0 {
+		return &fs.PathError{Op: "truncate", Path: f.name, Err: syscall.EINVAL}
+	}
+
+	if size <= int64(len(f.node.data)) {
+		f.node.data = f.node.data[:size]
+	} else {
+		f.node.data = append(f.node.data, make([]byte, size-int64(len(f.node.data)))...)
+	}
+	f.node.modTime = f.fs.now()
+
+	return nil
+}
+
+func (f *memFile) check(op string, write bool) error {
+	if f.closed {
+		return &fs.PathError{Op: op, Path: f.name, Err: fs.ErrClosed}
+	}
+	if write && !f.writable {
+		return &fs.PathError{Op: op, Path: f.name, Err: syscall.EBADF}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package syswrap
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemOSWrap_Env(t *testing.T) {
+	t.Run("valid: environment and home directory", func(t *testing.T) {
+		m := NewMemOSWrap()
+		m.Setenv("KEY", "value")
+		m.SetUserHomeDir("/home/user")
+
+		value, ok := m.LookupEnv("KEY")
+		assert.True(t, ok)
+		assert.Equal(t, "value", value)
+
+		_, ok = m.LookupEnv("MISSING")
+		assert.False(t, ok)
+
+		home, err := m.UserHomeDir()
+		assert.NoError(t, err)
+		assert.Equal(t, "/home/user", home)
+	})
+
+	t.Run("invalid: home directory is not set", func(t *testing.T) {
+		_, err := NewMemOSWrap().UserHomeDir()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestMemOSWrap_Chdir(t *testing.T) {
+	t.Run("valid: relative paths resolve against the working directory", func(t *testing.T) {
+		m := NewMemOSWrap()
+		dir := filepath.Join(string(filepath.Separator), "app")
+		require.NoError(t, m.MkdirAll(dir, 0o755))
+
+		require.NoError(t, m.Chdir(dir))
+		require.NoError(t, m.WriteFile("config.yaml", []byte("data"), 0o644))
+
+		wd, err := m.Getwd()
+		assert.NoError(t, err)
+		assert.Equal(t, dir, wd)
+		assert.True(t, m.FileExists(filepath.Join(dir, "config.yaml")))
+	})
+
+	t.Run("invalid: chdir to a file or a missing path", func(t *testing.T) {
+		m := NewMemOSWrap()
+		require.NoError(t, m.WriteFile("file", nil, 0o644))
+
+		assert.Error(t, m.Chdir("file"))
+		assert.ErrorIs(t, m.Chdir("missing"), fs.ErrNotExist)
+	})
+}
+
+func TestMemOSWrap_SetClock(t *testing.T) {
+	t.Run("valid: modification times follow the clock", func(t *testing.T) {
+		m := NewMemOSWrap()
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		m.SetClock(func() time.Time { return now })
+
+		require.NoError(t, m.Mkdir("dir", 0o755))
+		now = now.Add(time.Hour)
+		require.NoError(t, m.WriteFile(filepath.Join("dir", "file"), []byte("a"), 0o644))
+		created := now
+
+		now = now.Add(time.Hour)
+		require.NoError(t, m.Rename(filepath.Join("dir", "file"), "file"))
+
+		file, err := m.Stat("file")
+		require.NoError(t, err)
+		assert.Equal(t, created, file.ModTime())
+
+		dir, err := m.Stat("dir")
+		require.NoError(t, err)
+		assert.Equal(t, now, dir.ModTime())
+
+		now = now.Add(time.Hour)
+		f, err := m.CreateFile("file")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		file, err = m.Stat("file")
+		require.NoError(t, err)
+		assert.Equal(t, now, file.ModTime())
+	})
+}
@@ -5,12 +5,13 @@ import (
 	"compress/gzip"
 	"io"
 	"io/fs"
-	"os"
 	"path/filepath"
 
 	"github.com/4rchr4y/godevkit/v3/syswrap"
 )
 
+type File = syswrap.File
+
 type OSWrapper interface {
 	LookupEnv(key string) (string, bool)
 
@@ -19,12 +20,13 @@ type OSWrapper interface {
 
 	Rename(oldpath string, newpath string) error
 	Walk(root string, fn filepath.WalkFunc) error
+	WalkDir(root string, fn fs.WalkDirFunc) error
 	Mkdir(name string, perm fs.FileMode) error
 	MkdirAll(path string, perm fs.FileMode) error
 
-	CreateFile(name string) (*os.File, error)
+	CreateFile(name string) (File, error)
 	DeleteFile(filename string) error
-	OpenFile(name string) (*os.File, error)
+	OpenFile(name string) (File, error)
 	ReadFile(name string) ([]byte, error)
 	WriteFile(name string, data []byte, perm fs.FileMode) error
 	MoveFile(source string, target string) error
@@ -44,4 +46,7 @@ type OSWrapper interface {
 	WriteTar(writer io.Writer) *tar.Writer
 }
 
-var _ OSWrapper = (*syswrap.OSWrap)(nil)
+var (
+	_ OSWrapper = (*syswrap.OSWrap)(nil)
+	_ OSWrapper = (*syswrap.MemOSWrap)(nil)
+)
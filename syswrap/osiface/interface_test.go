@@ -0,0 +1,440 @@
+package osiface
+
+import (
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/4rchr4y/godevkit/v3/syswrap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSWrap(t *testing.T) {
+	testOSWrapper(t, func(t *testing.T) (OSWrapper, string) {
+		return syswrap.OSWrap{}, t.TempDir()
+	})
+}
+
+func TestMemOSWrap(t *testing.T) {
+	testOSWrapper(t, func(t *testing.T) (OSWrapper, string) {
+		osw := syswrap.NewMemOSWrap()
+		dir := filepath.Join(string(filepath.Separator), "work")
+		require.NoError(t, osw.MkdirAll(dir, 0o755))
+
+		return osw, dir
+	})
+}
+
+// testOSWrapper is the conformance suite every OSWrapper must pass. setup
+// returns a fresh wrapper and an existing empty directory to work in.
+func testOSWrapper(t *testing.T, setup func(t *testing.T) (OSWrapper, string)) {
+	t.Run("valid: create, write and read a file", func(t *testing.T) {
+		osw, dir := setup(t)
+		name := filepath.Join(dir, "file.txt")
+
+		f, err := osw.CreateFile(name)
+		require.NoError(t, err)
+		_, err = f.WriteString("hello")
+		require.NoError(t, err)
+		_, err = f.Write([]byte(" world"))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		data, err := osw.ReadFile(name)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", string(data))
+
+		info, err := osw.Stat(name)
+		require.NoError(t, err)
+		assert.Equal(t, "file.txt", info.Name())
+		assert.Equal(t, int64(11), info.Size())
+		assert.True(t, info.Mode().IsRegular())
+	})
+
+	t.Run("valid: create truncates an existing file", func(t *testing.T) {
+		osw, dir := setup(t)
+		name := filepath.Join(dir, "file.txt")
+		require.NoError(t, osw.WriteFile(name, []byte("old content"), 0o644))
+
+		f, err := osw.CreateFile(name)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		data, err := osw.ReadFile(name)
+		assert.NoError(t, err)
+		assert.Empty(t, data)
+	})
+
+	t.Run("valid: write file permissions are set on creation only", func(t *testing.T) {
+		osw, dir := setup(t)
+		name := filepath.Join(dir, "secret")
+
+		require.NoError(t, osw.WriteFile(name, []byte("a"), 0o600))
+		require.NoError(t, osw.WriteFile(name, []byte("b"), 0o644))
+
+		info, err := osw.Stat(name)
+		require.NoError(t, err)
+		assert.Equal(t, fs.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("valid: open file reads, seeks and stats", func(t *testing.T) {
+		osw, dir := setup(t)
+		name := filepath.Join(dir, "file.txt")
+		require.NoError(t, osw.WriteFile(name, []byte("0123456789"), 0o644))
+
+		f, err := osw.OpenFile(name)
+		require.NoError(t, err)
+		defer f.Close()
+
+		assert.Equal(t, name, f.Name())
+
+		buf := make([]byte, 4)
+		n, err := f.Read(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, "0123", string(buf[:n]))
+
+		offset, err := f.Seek(-2, io.SeekEnd)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(8), offset)
+
+		rest, err := io.ReadAll(f)
+		assert.NoError(t, err)
+		assert.Equal(t, "89", string(rest))
+
+		n, err = f.ReadAt(buf, 7)
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, "789", string(buf[:n]))
+
+		info, err := f.Stat()
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), info.Size())
+	})
+
+	t.Run("invalid: write to a file opened for reading", func(t *testing.T) {
+		osw, dir := setup(t)
+		name := filepath.Join(dir, "file.txt")
+		require.NoError(t, osw.WriteFile(name, []byte("data"), 0o644))
+
+		f, err := osw.OpenFile(name)
+		require.NoError(t, err)
+		defer f.Close()
+
+		_, err = f.Write([]byte("more"))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid: use of a closed file", func(t *testing.T) {
+		osw, dir := setup(t)
+
+		f, err := osw.CreateFile(filepath.Join(dir, "file.txt"))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		_, err = f.Write([]byte("data"))
+		assert.ErrorIs(t, err, fs.ErrClosed)
+		assert.ErrorIs(t, f.Close(), fs.ErrClosed)
+	})
+
+	t.Run("valid: modification time is set on write", func(t *testing.T) {
+		osw, dir := setup(t)
+		name := filepath.Join(dir, "file.txt")
+		before := time.Now().Add(-time.Second)
+
+		require.NoError(t, osw.WriteFile(name, []byte("data"), 0o644))
+
+		info, err := osw.Stat(name)
+		require.NoError(t, err)
+		assert.WithinRange(t, info.ModTime(), before, time.Now().Add(time.Second))
+	})
+
+	t.Run("invalid: missing file", func(t *testing.T) {
+		osw, dir := setup(t)
+		name := filepath.Join(dir, "missing")
+
+		_, err := osw.ReadFile(name)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+
+		_, err = osw.OpenFile(name)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+
+		_, err = osw.Stat(name)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+
+		exists, err := osw.Exists(name)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		assert.False(t, osw.FileExists(name))
+		assert.False(t, osw.DirExists(name))
+	})
+
+	t.Run("invalid: missing parent directory", func(t *testing.T) {
+		osw, dir := setup(t)
+		name := filepath.Join(dir, "missing", "file.txt")
+
+		assert.ErrorIs(t, osw.WriteFile(name, []byte("data"), 0o644), fs.ErrNotExist)
+
+		_, err := osw.CreateFile(name)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+
+		assert.ErrorIs(t, osw.Mkdir(name, 0o755), fs.ErrNotExist)
+	})
+
+	t.Run("invalid: file used as a directory", func(t *testing.T) {
+		osw, dir := setup(t)
+		file := filepath.Join(dir, "file.txt")
+		require.NoError(t, osw.WriteFile(file, []byte("data"), 0o644))
+
+		_, err := osw.Stat(filepath.Join(file, "nested"))
+		assert.ErrorIs(t, err, syscall.ENOTDIR)
+
+		assert.ErrorIs(t, osw.MkdirAll(filepath.Join(file, "nested"), 0o755), syscall.ENOTDIR)
+	})
+
+	t.Run("valid: directories", func(t *testing.T) {
+		osw, dir := setup(t)
+		nested := filepath.Join(dir, "a", "b", "c")
+
+		require.NoError(t, osw.Mkdir(filepath.Join(dir, "single"), 0o750))
+		require.NoError(t, osw.MkdirAll(nested, 0o755))
+		require.NoError(t, osw.MkdirAll(nested, 0o755))
+
+		info, err := osw.Stat(filepath.Join(dir, "single"))
+		require.NoError(t, err)
+		assert.True(t, info.IsDir())
+		assert.Equal(t, fs.FileMode(0o750), info.Mode().Perm())
+
+		assert.True(t, osw.DirExists(nested))
+		assert.False(t, osw.FileExists(nested))
+		assert.ErrorIs(t, osw.Mkdir(nested, 0o755), fs.ErrExist)
+
+		exists, err := osw.Exists(filepath.Join(dir, "a", "b"))
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("valid: dir is empty", func(t *testing.T) {
+		osw, dir := setup(t)
+
+		empty, err := osw.DirIsEmpty(dir)
+		assert.NoError(t, err)
+		assert.True(t, empty)
+
+		require.NoError(t, osw.WriteFile(filepath.Join(dir, "file.txt"), nil, 0o644))
+
+		empty, err = osw.DirIsEmpty(dir)
+		assert.NoError(t, err)
+		assert.False(t, empty)
+	})
+
+	t.Run("invalid: dir is empty on a file or a missing path", func(t *testing.T) {
+		osw, dir := setup(t)
+		file := filepath.Join(dir, "file.txt")
+		require.NoError(t, osw.WriteFile(file, nil, 0o644))
+
+		_, err := osw.DirIsEmpty(file)
+		assert.Error(t, err)
+
+		_, err = osw.DirIsEmpty(filepath.Join(dir, "missing"))
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("valid: read directory names", func(t *testing.T) {
+		osw, dir := setup(t)
+		for _, name := range []string{"b", "a", "c"} {
+			require.NoError(t, osw.WriteFile(filepath.Join(dir, name), nil, 0o644))
+		}
+
+		f, err := osw.OpenFile(dir)
+		require.NoError(t, err)
+		defer f.Close()
+
+		names, err := f.Readdirnames(-1)
+		assert.NoError(t, err)
+		sort.Strings(names)
+		assert.Equal(t, []string{"a", "b", "c"}, names)
+
+		_, err = f.Readdirnames(1)
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("valid: delete", func(t *testing.T) {
+		osw, dir := setup(t)
+		file := filepath.Join(dir, "file.txt")
+		sub := filepath.Join(dir, "sub")
+		require.NoError(t, osw.WriteFile(file, nil, 0o644))
+		require.NoError(t, osw.Mkdir(sub, 0o755))
+
+		assert.NoError(t, osw.DeleteFile(file))
+		assert.NoError(t, osw.DeleteFile(sub))
+		assert.False(t, osw.FileExists(file))
+		assert.False(t, osw.DirExists(sub))
+	})
+
+	t.Run("invalid: delete", func(t *testing.T) {
+		osw, dir := setup(t)
+		sub := filepath.Join(dir, "sub")
+		require.NoError(t, osw.MkdirAll(filepath.Join(sub, "nested"), 0o755))
+
+		assert.ErrorIs(t, osw.DeleteFile(sub), syscall.ENOTEMPTY)
+		assert.ErrorIs(t, osw.DeleteFile(filepath.Join(dir, "missing")), fs.ErrNotExist)
+		assert.True(t, osw.DirExists(sub))
+	})
+
+	t.Run("valid: rename a file over another file", func(t *testing.T) {
+		osw, dir := setup(t)
+		oldpath, newpath := filepath.Join(dir, "old"), filepath.Join(dir, "new")
+		require.NoError(t, osw.WriteFile(oldpath, []byte("old"), 0o600))
+		require.NoError(t, osw.WriteFile(newpath, []byte("new"), 0o644))
+
+		require.NoError(t, osw.Rename(oldpath, newpath))
+
+		data, err := osw.ReadFile(newpath)
+		assert.NoError(t, err)
+		assert.Equal(t, "old", string(data))
+		assert.False(t, osw.FileExists(oldpath))
+
+		info, err := osw.Stat(newpath)
+		require.NoError(t, err)
+		assert.Equal(t, fs.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("valid: rename a directory moves its content", func(t *testing.T) {
+		osw, dir := setup(t)
+		src, dst := filepath.Join(dir, "src"), filepath.Join(dir, "dst", "moved")
+		require.NoError(t, osw.MkdirAll(filepath.Join(src, "nested"), 0o755))
+		require.NoError(t, osw.WriteFile(filepath.Join(src, "nested", "file.txt"), []byte("data"), 0o644))
+		require.NoError(t, osw.Mkdir(filepath.Join(dir, "dst"), 0o755))
+
+		require.NoError(t, osw.MoveFile(src, dst))
+
+		data, err := osw.ReadFile(filepath.Join(dst, "nested", "file.txt"))
+		assert.NoError(t, err)
+		assert.Equal(t, "data", string(data))
+		assert.False(t, osw.DirExists(src))
+	})
+
+	t.Run("valid: rename keeps open files usable", func(t *testing.T) {
+		osw, dir := setup(t)
+		oldpath, newpath := filepath.Join(dir, "old"), filepath.Join(dir, "new")
+
+		f, err := osw.CreateFile(oldpath)
+		require.NoError(t, err)
+		defer f.Close()
+
+		require.NoError(t, osw.Rename(oldpath, newpath))
+		_, err = f.WriteString("data")
+		require.NoError(t, err)
+
+		data, err := osw.ReadFile(newpath)
+		assert.NoError(t, err)
+		assert.Equal(t, "data", string(data))
+	})
+
+	t.Run("invalid: rename", func(t *testing.T) {
+		osw, dir := setup(t)
+		file := filepath.Join(dir, "file.txt")
+		sub := filepath.Join(dir, "sub")
+		require.NoError(t, osw.WriteFile(file, []byte("data"), 0o644))
+		require.NoError(t, osw.Mkdir(sub, 0o755))
+
+		assert.ErrorIs(t, osw.Rename(filepath.Join(dir, "missing"), file), fs.ErrNotExist)
+		assert.ErrorIs(t, osw.Rename(file, filepath.Join(dir, "missing", "file.txt")), fs.ErrNotExist)
+		assert.ErrorIs(t, osw.Rename(file, sub), fs.ErrExist)
+		assert.ErrorIs(t, osw.Rename(sub, file), syscall.ENOTDIR)
+		assert.ErrorIs(t, osw.Rename(sub, filepath.Join(sub, "inner")), syscall.EINVAL)
+
+		assert.True(t, osw.FileExists(file))
+		assert.True(t, osw.DirExists(sub))
+	})
+
+	t.Run("valid: walk in lexical order", func(t *testing.T) {
+		osw, dir := setup(t)
+		writeTree(t, osw, dir)
+
+		var walked, walkedDir []string
+		err := osw.Walk(dir, func(path string, info fs.FileInfo, err error) error {
+			require.NoError(t, err)
+			walked = append(walked, relPath(t, dir, path))
+			return nil
+		})
+		require.NoError(t, err)
+
+		err = osw.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			require.NoError(t, err)
+			walkedDir = append(walkedDir, relPath(t, dir, path))
+			return nil
+		})
+		require.NoError(t, err)
+
+		expected := []string{".", "a.txt", "b", "b/x.txt", "b/y", "b/y/z.txt", "c.txt"}
+		assert.Equal(t, expected, walked)
+		assert.Equal(t, expected, walkedDir)
+	})
+
+	t.Run("valid: walk skips", func(t *testing.T) {
+		osw, dir := setup(t)
+		writeTree(t, osw, dir)
+
+		var walked []string
+		err := osw.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			rel := relPath(t, dir, path)
+			walked = append(walked, rel)
+
+			switch rel {
+			case "b/y":
+				return filepath.SkipDir
+			case "c.txt":
+				return filepath.SkipAll
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{".", "a.txt", "b", "b/x.txt", "b/y", "c.txt"}, walked)
+
+		walked = nil
+		err = osw.Walk(dir, func(path string, info fs.FileInfo, err error) error {
+			rel := relPath(t, dir, path)
+			walked = append(walked, rel)
+
+			if rel == "b/x.txt" {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{".", "a.txt", "b", "b/x.txt", "c.txt"}, walked)
+	})
+
+	t.Run("invalid: walk a missing root", func(t *testing.T) {
+		osw, dir := setup(t)
+		root := filepath.Join(dir, "missing")
+
+		var walkErr error
+		err := osw.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			walkErr = err
+			return err
+		})
+
+		assert.ErrorIs(t, walkErr, fs.ErrNotExist)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
+
+func writeTree(t *testing.T, osw OSWrapper, dir string) {
+	require.NoError(t, osw.MkdirAll(filepath.Join(dir, "b", "y"), 0o755))
+	for _, name := range []string{"c.txt", "a.txt", "b/y/z.txt", "b/x.txt"} {
+		require.NoError(t, osw.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), nil, 0o644))
+	}
+}
+
+func relPath(t *testing.T, dir string, path string) string {
+	rel, err := filepath.Rel(dir, path)
+	require.NoError(t, err)
+
+	return filepath.ToSlash(rel)
+}
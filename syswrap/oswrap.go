@@ -15,8 +15,13 @@ func (OSWrap) Rename(oldpath string, newpath string) error {
 	return os.Rename(oldpath, newpath)
 }
 
-func (OSWrap) CreateFile(name string) (*os.File, error) {
-	return os.Create(name)
+func (OSWrap) CreateFile(name string) (File, error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
 }
 
 func (OSWrap) DeleteFile(filename string) error {
@@ -27,8 +32,13 @@ func (OSWrap) WriteFile(name string, data []byte, perm fs.FileMode) error {
 	return os.WriteFile(name, data, perm)
 }
 
-func (OSWrap) OpenFile(name string) (*os.File, error) {
-	return os.Open(name)
+func (OSWrap) OpenFile(name string) (File, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
 }
 
 func (OSWrap) MoveFile(source string, target string) error {